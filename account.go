@@ -0,0 +1,207 @@
+package akismet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+// UsageLimit is a usage of API key in current month, returned by usage-limit endpoint
+type UsageLimit struct {
+	// Limit is a monthly limit of API calls, it is 0 when Unlimited is true
+	Limit      int64
+	Unlimited  bool
+	Usage      int64
+	Percentage float64
+	Throttled  bool
+}
+
+// KeySitesFilter sets order and pagination of key-sites results. Order is one of
+// "total", "spam", "ham", "missed_spam", "false_positives", "is_revoking".
+type KeySitesFilter struct {
+	Order  string
+	Limit  int
+	Offset int
+}
+
+// KeySite is usage statistics of single site using API key
+type KeySite struct {
+	Site           string
+	APICalls       int64
+	Spam           int64
+	Ham            int64
+	MissedSpam     int64
+	FalsePositives int64
+	IsRevoking     bool
+}
+
+// KeySites is a list of sites using API key in Month, returned by key-sites endpoint
+type KeySites struct {
+	Month  string
+	Sites  []KeySite
+	Limit  int
+	Offset int
+	Total  int
+}
+
+// UsageLimit returns usage of API key in current month
+func (c *Client) UsageLimit(ctx context.Context) (*UsageLimit, error) {
+	v := url.Values{}
+	v.Add("api_key", c.apiKey)
+
+	r, err := c.call(ctx, EndpointUsageLimit, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		Limit      flexNumber `json:"limit"`
+		Usage      flexNumber `json:"usage"`
+		Percentage flexNumber `json:"percentage"`
+		Throttled  bool       `json:"throttled"`
+	}
+	if err := json.Unmarshal([]byte(r.body), &res); err != nil {
+		return nil, r.error(accountError(r))
+	}
+
+	limit := &UsageLimit{
+		Usage:      int64(res.Usage.value),
+		Percentage: res.Percentage.value,
+		Throttled:  res.Throttled,
+	}
+	if res.Limit.none {
+		limit.Unlimited = true
+	} else {
+		limit.Limit = int64(res.Limit.value)
+	}
+
+	return limit, nil
+}
+
+var monthPattern = regexp.MustCompile(`^\d{4}-\d{2}$`)
+
+// KeySites returns sites which used API key in month (format "2006-01"), empty month means current month.
+// Only JSON format of key-sites endpoint is supported.
+func (c *Client) KeySites(ctx context.Context, month string, filter KeySitesFilter) (*KeySites, error) {
+	v := url.Values{}
+	v.Add("api_key", c.apiKey)
+	v.Add("format", "json")
+	if month != "" {
+		v.Add("month", month)
+	}
+
+	if filter.Order != "" {
+		v.Add("order", filter.Order)
+	}
+
+	if filter.Limit > 0 {
+		v.Add("limit", strconv.Itoa(filter.Limit))
+	}
+
+	if filter.Offset > 0 {
+		v.Add("offset", strconv.Itoa(filter.Offset))
+	}
+
+	r, err := c.call(ctx, EndpointKeySites, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	var res map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(r.body), &res); err != nil {
+		return nil, r.error(accountError(r))
+	}
+
+	sites := &KeySites{Month: month}
+	var limit, offset, total flexNumber
+	for key, raw := range res {
+		switch key {
+		case "limit":
+			err = json.Unmarshal(raw, &limit)
+		case "offset":
+			err = json.Unmarshal(raw, &offset)
+		case "total":
+			err = json.Unmarshal(raw, &total)
+		default:
+			if monthPattern.MatchString(key) && (month == "" || key == month) {
+				sites.Month = key
+				sites.Sites, err = parseKeySites(raw)
+			}
+		}
+
+		if err != nil {
+			return nil, r.error(ErrUnexpectedResponse)
+		}
+	}
+	sites.Limit, sites.Offset, sites.Total = int(limit.value), int(offset.value), int(total.value)
+
+	return sites, nil
+}
+
+func parseKeySites(raw json.RawMessage) ([]KeySite, error) {
+	var res []struct {
+		Site           string     `json:"site"`
+		APICalls       flexNumber `json:"api_calls"`
+		Spam           flexNumber `json:"spam"`
+		Ham            flexNumber `json:"ham"`
+		MissedSpam     flexNumber `json:"missed_spam"`
+		FalsePositives flexNumber `json:"false_positives"`
+		IsRevoking     bool       `json:"is_revoking"`
+	}
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return nil, err
+	}
+
+	sites := make([]KeySite, 0, len(res))
+	for _, s := range res {
+		sites = append(sites, KeySite{
+			Site:           s.Site,
+			APICalls:       int64(s.APICalls.value),
+			Spam:           int64(s.Spam.value),
+			Ham:            int64(s.Ham.value),
+			MissedSpam:     int64(s.MissedSpam.value),
+			FalsePositives: int64(s.FalsePositives.value),
+			IsRevoking:     s.IsRevoking,
+		})
+	}
+
+	return sites, nil
+}
+
+// accountError returns error for response which is not a JSON document
+func accountError(r *apiResponse) error {
+	if r.body == "invalid" {
+		return ErrInvalidKey
+	}
+
+	return ErrUnexpectedResponse
+}
+
+// flexNumber is a JSON number which Akismet sends as number, numeric string or "none"
+type flexNumber struct {
+	value float64
+	none  bool
+}
+
+func (n *flexNumber) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(b, `"`)
+	switch string(b) {
+	case "none":
+		n.none = true
+		return nil
+	case "null", "":
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(string(b), 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %q", b)
+	}
+
+	n.value = value
+	return nil
+}
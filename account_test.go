@@ -0,0 +1,115 @@
+package akismet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageLimit(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var received *http.Request
+	httpmock.RegisterResponder("GET", "https://rest.akismet.com/1.2/usage-limit", func(req *http.Request) (*http.Response, error) {
+		received = req
+		return httpmock.NewStringResponse(200, `{"limit":350000,"usage":7463,"percentage":"2.13","throttled":false}`), nil
+	})
+
+	client := NewClient("test_api_key", "test_site")
+	limit, err := client.UsageLimit(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &UsageLimit{Limit: 350000, Usage: 7463, Percentage: 2.13}, limit)
+	if assert.NotNil(t, received) {
+		assert.Equal(t, "test_api_key", received.URL.Query().Get("api_key"))
+	}
+}
+
+func TestUsageLimitUnlimited(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://rest.akismet.com/1.2/usage-limit", httpmock.NewStringResponder(200, `{"limit":"none","usage":"7463","percentage":null,"throttled":true}`))
+
+	client := NewClient("test_api_key", "test_site")
+	limit, err := client.UsageLimit(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &UsageLimit{Unlimited: true, Usage: 7463, Throttled: true}, limit)
+}
+
+func TestUsageLimitInvalidKey(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://rest.akismet.com/1.2/usage-limit", httpmock.NewStringResponder(200, "invalid"))
+
+	client := NewClient("test_api_key", "test_site")
+	_, err := client.UsageLimit(context.Background())
+	assert.True(t, errors.Is(err, ErrInvalidKey))
+}
+
+func TestKeySites(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var received *http.Request
+	httpmock.RegisterResponder("GET", "https://rest.akismet.com/1.2/key-sites", func(req *http.Request) (*http.Response, error) {
+		received = req
+		return httpmock.NewStringResponse(200, `{
+			"2022-09": [
+				{"site":"example.com","api_calls":"2072","spam":"2069","ham":"3","missed_spam":"0","false_positives":"4","is_revoking":false},
+				{"site":"example.org","api_calls":1000,"spam":10,"ham":990,"missed_spam":1,"false_positives":0,"is_revoking":true}
+			],
+			"limit": 10,
+			"offset": 0,
+			"total": 2
+		}`), nil
+	})
+
+	client := NewClient("test_api_key", "test_site")
+	sites, err := client.KeySites(context.Background(), "2022-09", KeySitesFilter{Order: "spam", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, &KeySites{
+		Month: "2022-09",
+		Sites: []KeySite{
+			{Site: "example.com", APICalls: 2072, Spam: 2069, Ham: 3, FalsePositives: 4},
+			{Site: "example.org", APICalls: 1000, Spam: 10, Ham: 990, MissedSpam: 1, IsRevoking: true},
+		},
+		Limit: 10,
+		Total: 2,
+	}, sites)
+
+	if assert.NotNil(t, received) {
+		q := received.URL.Query()
+		assert.Equal(t, "test_api_key", q.Get("api_key"))
+		assert.Equal(t, "2022-09", q.Get("month"))
+		assert.Equal(t, "spam", q.Get("order"))
+		assert.Equal(t, "10", q.Get("limit"))
+		assert.Equal(t, "", q.Get("offset"))
+		assert.Equal(t, "json", q.Get("format"))
+	}
+}
+
+func TestKeySitesCurrentMonth(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://rest.akismet.com/1.2/key-sites", httpmock.NewStringResponder(200, `{"2023-01":[],"limit":10,"offset":0,"total":0,"next_page":"none"}`))
+
+	client := NewClient("test_api_key", "test_site")
+	sites, err := client.KeySites(context.Background(), "", KeySitesFilter{})
+	assert.Nil(t, err)
+	assert.Equal(t, "2023-01", sites.Month)
+	assert.Empty(t, sites.Sites)
+}
+
+func TestKeySitesMalformed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://rest.akismet.com/1.2/key-sites", httpmock.NewStringResponder(200, `{"2023-01":"oops"}`))
+
+	client := NewClient("test_api_key", "test_site")
+	_, err := client.KeySites(context.Background(), "", KeySitesFilter{})
+	assert.True(t, errors.Is(err, ErrUnexpectedResponse))
+}
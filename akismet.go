@@ -2,11 +2,17 @@
 package akismet
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,204 +21,483 @@ const (
 	APIAddress              = "rest.akismet.com"
 	APIProtocol             = "https"
 	APIVersion              = "1.1"
+	AccountAPIVersion       = "1.2"
 	DateFormat              = time.RFC3339
 	SubmitResponseContentOK = "Thanks for making the web a better place."
+	LibraryVersion          = "1.1.0"
 )
 
+const defaultUserAgent = "Akismet-go/" + LibraryVersion
+
 // Client is Akismet client struct
 type Client struct {
 	apiKey     string
 	site       string
 	httpClient *http.Client
+	registry   Registry
+	userAgent  string
+	timeout    time.Duration
+	retry      RetryPolicy
+
+	checkLimiter  *rateLimiter
+	submitLimiter *rateLimiter
+	breaker       *circuitBreaker
+	cache         Cache
+	fingerprint   FingerprintFunc
+	metrics       Metrics
+
+	logger         *slog.Logger
+	redactedParams []string
+	tracer         Tracer
 }
 
-// Options is a struct which contains all of possible arguments for Akismet
+// Options is a struct which contains all of possible arguments for Akismet. JSON names of
+// fields are names of Akismet parameters. Created and Modified are in RFC 3339 format or
+// Unix timestamps, like Akismet parameters.
 type Options struct {
-	UserIP      string
-	UserAgent   string
-	Referrer    string
-	Permalink   string
-	Author      string
-	AuthorEmail string
-	AuthorURL   string
-	Content     string
-	Created     string
-	Modified    string
-	Lang        string
-	Charset     string
-	UserRole    string
-	IsTest      string
-}
-
-type apiEndpoint struct {
-	path           string
-	method         string
-	apiKeyRequired bool
-}
-
-var apiEndpoints = map[string]apiEndpoint{
-	"verifyKey":    apiEndpoint{"verify-key", "GET", false},
-	"commentCheck": apiEndpoint{"comment-check", "POST", true},
-	"submitSpam":   apiEndpoint{"submit-spam", "POST", true},
-	"submitHam":    apiEndpoint{"submit-ham", "POST", true},
+	UserIP      string `json:"user_ip,omitempty"`
+	UserAgent   string `json:"user_agent,omitempty"`
+	Referrer    string `json:"referrer,omitempty"`
+	Permalink   string `json:"permalink,omitempty"`
+	Author      string `json:"comment_author,omitempty"`
+	AuthorEmail string `json:"comment_author_email,omitempty"`
+	AuthorURL   string `json:"comment_author_url,omitempty"`
+	Content     string `json:"comment_content,omitempty"`
+	Created     string `json:"comment_date_gmt,omitempty"`
+	Modified    string `json:"comment_post_modified_gmt,omitempty"`
+	Lang        string `json:"blog_lang,omitempty"`
+	Charset     string `json:"blog_charset,omitempty"`
+	UserRole    string `json:"user_role,omitempty"`
+	IsTest      string `json:"is_test,omitempty"`
+
+	CommentType       CommentType `json:"comment_type,omitempty"`
+	HoneypotFieldName string      `json:"honeypot_field_name,omitempty"`
+	HoneypotValue     string      `json:"honeypot_value,omitempty"`
+	RecheckReason     string      `json:"recheck_reason,omitempty"`
+	CommentContext    []string    `json:"comment_context,omitempty"`
+	CommentParent     string      `json:"comment_parent,omitempty"`
+	// ServerEnv keys must be upper case CGI-style names, e.g. HTTP_ACCEPT, REMOTE_PORT, SERVER_NAME
+	ServerEnv map[string]string `json:"server_env,omitempty"`
 }
 
-// NewClient is function which create new Akismet client
-func NewClient(apiKey, site string) *Client {
-	return &Client{
-		apiKey:     apiKey,
-		site:       site,
-		httpClient: &http.Client{},
+// CommentType is a type of submitted content, sent as comment_type
+type CommentType string
+
+// Comment types recognized by Akismet
+const (
+	CommentTypeComment     CommentType = "comment"
+	CommentTypeForumPost   CommentType = "forum-post"
+	CommentTypeReply       CommentType = "reply"
+	CommentTypeBlogPost    CommentType = "blog-post"
+	CommentTypeContactForm CommentType = "contact-form"
+	CommentTypeSignup      CommentType = "signup"
+	CommentTypeMessage     CommentType = "message"
+)
+
+var langPattern = regexp.MustCompile(`^[a-zA-Z]{2,3}([_-][a-zA-Z0-9]+)*$`)
+
+var serverEnvPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// reservedParams are Akismet API parameters which can not be used as honeypot field name,
+// all comment_ prefixed names are reserved as well
+var reservedParams = map[string]bool{
+	"api_key": true, "key": true, "blog": true, "user_ip": true, "user_agent": true,
+	"referrer": true, "permalink": true, "blog_lang": true, "blog_charset": true,
+	"user_role": true, "is_test": true, "honeypot_field_name": true, "recheck_reason": true,
+}
+
+// SetUserIP sets UserIP from IPv4 or IPv6 address
+func (o *Options) SetUserIP(ip net.IP) error {
+	if ip == nil || ip.To16() == nil {
+		return fmt.Errorf("invalid IP address %v", ip)
 	}
+
+	o.UserIP = ip.String()
+	return nil
 }
 
-// VeryfiClient is method which check key & site parameters are valid
-func (c *Client) VeryfiClient() error {
-	endpointURL, err := c.getEndpointURL("verifyKey")
+// SetCreated sets Created from time.Time, zero time and years outside 0-9999 are invalid
+func (o *Options) SetCreated(t time.Time) error {
+	created, err := formatDate(t)
 	if err != nil {
 		return err
 	}
 
-	address, err := url.Parse(endpointURL)
+	o.Created = created
+	return nil
+}
+
+// SetModified sets Modified from time.Time, zero time and years outside 0-9999 are invalid
+func (o *Options) SetModified(t time.Time) error {
+	modified, err := formatDate(t)
 	if err != nil {
 		return err
 	}
-	v := address.Query()
+
+	o.Modified = modified
+	return nil
+}
+
+// formatDate formats t in DateFormat, which can not represent years outside 0-9999
+func formatDate(t time.Time) (string, error) {
+	if t.IsZero() || t.Year() < 0 || t.Year() > 9999 {
+		return "", fmt.Errorf("invalid date %v", t)
+	}
+
+	return t.Format(DateFormat), nil
+}
+
+// SetTest marks request as a test query
+func (o *Options) SetTest(isTest bool) {
+	o.IsTest = ""
+	if isTest {
+		o.IsTest = "true"
+	}
+}
+
+// SetLang sets Lang from list of locales in ISO 639-1 format, e.g. "en", "fr_ca"
+func (o *Options) SetLang(langs ...string) error {
+	for _, lang := range langs {
+		if !langPattern.MatchString(lang) {
+			return fmt.Errorf("invalid language %q", lang)
+		}
+	}
+
+	o.Lang = strings.Join(langs, ", ")
+	return nil
+}
+
+// Endpoint describes single API endpoint. APIKeyRequired endpoints get API key
+// according to Registry.Auth, empty Version means Registry.Version.
+type Endpoint struct {
+	Path           string
+	Method         string
+	APIKeyRequired bool
+	Version        string
+}
+
+// Names of built-in endpoints in Registry.Endpoints
+const (
+	EndpointVerifyKey    = "verifyKey"
+	EndpointCommentCheck = "commentCheck"
+	EndpointSubmitSpam   = "submitSpam"
+	EndpointSubmitHam    = "submitHam"
+	EndpointUsageLimit   = "usageLimit"
+	EndpointKeySites     = "keySites"
+)
+
+var apiEndpoints = map[string]Endpoint{
+	EndpointVerifyKey:    Endpoint{"verify-key", "GET", false, ""},
+	EndpointCommentCheck: Endpoint{"comment-check", "POST", true, ""},
+	EndpointSubmitSpam:   Endpoint{"submit-spam", "POST", true, ""},
+	EndpointSubmitHam:    Endpoint{"submit-ham", "POST", true, ""},
+	EndpointUsageLimit:   Endpoint{"usage-limit", "GET", false, AccountAPIVersion},
+	EndpointKeySites:     Endpoint{"key-sites", "GET", false, AccountAPIVersion},
+}
+
+// NewClient is function which create new Akismet client
+func NewClient(apiKey, site string, opts ...Option) *Client {
+	c := &Client{
+		apiKey:     apiKey,
+		site:       site,
+		httpClient: &http.Client{},
+		registry:   DefaultRegistry(),
+		userAgent:  defaultUserAgent,
+
+		redactedParams: DefaultRedactedParams,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.timeout > 0 {
+		httpClient := *c.httpClient
+		httpClient.Timeout = c.timeout
+		c.httpClient = &httpClient
+	}
+
+	return c
+}
+
+// VerifyKey is method which check key & site parameters are valid.
+// For invalid key returned error wraps ErrInvalidKey and contains X-akismet-debug-help header.
+func (c *Client) VerifyKey(ctx context.Context) error {
+	return c.traced(ctx, "verify-key", c.verifyKey)
+}
+
+func (c *Client) verifyKey(ctx context.Context) error {
+	v := url.Values{}
 	v.Add("key", c.apiKey)
 	v.Add("blog", c.site)
-	address.RawQuery = v.Encode()
 
-	// Error not possible, we parsing URL address before make a request
-	res, _ := c.httpClient.Get(address.String())
+	r, err := c.call(ctx, EndpointVerifyKey, &v)
+	if err != nil {
+		return err
+	}
 
-	r, _ := getResponseBodyAsString(res)
-	if r == "valid" {
+	if r.body == "valid" {
 		return nil
 	}
 
-	return errors.New("invalid key or blog")
+	return r.error(ErrInvalidKey)
+}
+
+// VeryfiClient is method which check key & site parameters are valid
+//
+// Deprecated: use VerifyKey instead.
+func (c *Client) VeryfiClient() error {
+	return c.VerifyKey(context.Background())
+}
+
+// VeryfiClientContext is like VeryfiClient but the request is bound to ctx
+//
+// Deprecated: use VerifyKey instead.
+func (c *Client) VeryfiClientContext(ctx context.Context) error {
+	return c.VerifyKey(ctx)
 }
 
 // IsSpam is a method which check if passed Options struct is spam or not
 func (c *Client) IsSpam(o Options) (bool, error) {
-	r, err := c.makeRequest(o, "commentCheck")
+	return c.IsSpamContext(context.Background(), o)
+}
+
+// IsSpamContext is like IsSpam but the request is bound to ctx
+func (c *Client) IsSpamContext(ctx context.Context, o Options) (bool, error) {
+	r, err := c.CheckContext(ctx, o)
 	if err != nil {
 		return false, err
 	}
 
-	switch r {
-	case "true":
-		return true, nil
-	case "invalid":
-		return false, errors.New("bad request")
-	}
-
-	return false, nil
+	return r.IsSpam(), nil
 }
 
 // SubmitSpam is method which send to Akismet API request about found spam
 func (c *Client) SubmitSpam(o Options) error {
-	r, err := c.makeRequest(o, "submitSpam")
-	if err != nil {
-		return err
-	}
-
-	if r != SubmitResponseContentOK {
-		return internalError()
-	}
+	return c.SubmitSpamContext(context.Background(), o)
+}
 
-	return nil
+// SubmitSpamContext is like SubmitSpam but the request is bound to ctx
+func (c *Client) SubmitSpamContext(ctx context.Context, o Options) error {
+	return c.traced(ctx, "submit-spam", func(ctx context.Context) error {
+		return c.submit(ctx, o, EndpointSubmitSpam)
+	})
 }
 
 // SubmitHam is method which send to Akismet API request about found ham
 func (c *Client) SubmitHam(o Options) error {
-	r, err := c.makeRequest(o, "submitHam")
+	return c.SubmitHamContext(context.Background(), o)
+}
+
+// SubmitHamContext is like SubmitHam but the request is bound to ctx
+func (c *Client) SubmitHamContext(ctx context.Context, o Options) error {
+	return c.traced(ctx, "submit-ham", func(ctx context.Context) error {
+		return c.submit(ctx, o, EndpointSubmitHam)
+	})
+}
+
+func (c *Client) submit(ctx context.Context, o Options, endpointName string) error {
+	r, err := c.makeRequest(ctx, o, endpointName)
 	if err != nil {
 		return err
 	}
 
-	if r != SubmitResponseContentOK {
-		return internalError()
+	switch r.body {
+	case SubmitResponseContentOK:
+		c.invalidate(o)
+		return nil
+	case "invalid":
+		return r.error(ErrInvalidRequest)
 	}
 
-	return nil
+	return r.error(ErrUnexpectedResponse)
 }
 
-func (c *Client) makeRequest(o Options, endpointName string) (string, error) {
+func (c *Client) makeRequest(ctx context.Context, o Options, endpointName string) (*apiResponse, error) {
 	v, err := o.parse()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	v.Add("blog", c.site)
+	return c.call(ctx, endpointName, v)
+}
+
+// call sends v to endpoint, retrying transient failures according to retry policy
+func (c *Client) call(ctx context.Context, endpointName string, v *url.Values) (*apiResponse, error) {
+	endpoint, err := c.registry.endpoint(endpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.APIKeyRequired && c.registry.Auth == KeyAsParameter {
+		v.Add("api_key", c.apiKey)
+	}
+
 	endpointURL, err := c.getEndpointURL(endpointName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	address, err := url.Parse(endpointURL)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if !c.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	span := spanFromContext(ctx)
+	if span == nil {
+		ctx, span = c.startSpan(ctx, endpoint.Path)
+		defer func() { span.end(err) }()
+	}
+
+	start := time.Now()
+	r, retries, err := c.retryLoop(ctx, endpointName, endpoint, address, v)
+	c.breaker.record(ctx, err)
+	span.recordCall(statusCode(r, err), retries)
+	if c.metrics != nil {
+		c.metrics.ObserveRequest(endpoint.Path, statusCode(r, err), err, time.Since(start))
 	}
 
-	address.RawQuery = v.Encode()
-	res, err := c.httpClient.Get(address.String())
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// retryLoop sends request until it succeeds or retry policy gives up, it returns number of retries
+func (c *Client) retryLoop(ctx context.Context, endpointName string, endpoint *Endpoint, address *url.URL, v *url.Values) (*apiResponse, int, error) {
+	for attempt := 1; ; attempt++ {
+		if err := c.limiter(endpointName).wait(ctx); err != nil {
+			return nil, attempt - 1, err
+		}
+
+		r, err := c.send(ctx, endpoint, *address, v.Encode())
+		if err == nil && r.statusCode == http.StatusOK {
+			return r, attempt - 1, nil
+		}
+
+		if err == nil {
+			err = r.error(ErrUnexpectedStatus)
+		}
+
+		delay, ok := c.retry.delay(ctx, endpointName, attempt, r, err)
+		if !ok {
+			return r, attempt - 1, err
+		}
+
+		if c.retry.OnRetry != nil {
+			c.retry.OnRetry(RetryEvent{Endpoint: endpoint.Path, Attempt: attempt + 1, Delay: delay, Err: err})
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, attempt - 1, err
+		}
+	}
+}
+
+// send makes single request to endpoint and reads response of any status code
+func (c *Client) send(ctx context.Context, endpoint *Endpoint, address url.URL, form string) (*apiResponse, error) {
+	var body io.Reader
+	if endpoint.Method == "POST" {
+		body = strings.NewReader(form)
+	} else {
+		address.RawQuery = form
+	}
+
+	req, err := c.newRequest(ctx, endpoint.Method, address.String(), body)
+	if err != nil {
+		return nil, err
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return "", errors.New("something went wrong, HTTP status code is not equals 200")
+	start := time.Now()
+	c.logRequest(ctx, endpoint, address, form)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logFailure(ctx, endpoint, err, time.Since(start))
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	r, err := readResponse(res, endpoint.Path)
+	if err != nil {
+		c.logFailure(ctx, endpoint, err, time.Since(start))
+		return nil, err
 	}
 
-	return getResponseBodyAsString(res)
+	c.logResponse(ctx, r, time.Since(start))
+	return r, nil
 }
 
 func (c *Client) getEndpointURL(name string) (string, error) {
-	endpoint, err := getEndpoint(name)
+	endpoint, err := c.registry.endpoint(name)
 	if err != nil {
 		return "", err
 	}
 
+	base, err := url.Parse(c.registry.BaseURL)
+	if err != nil {
+		return "", err
+	}
+
+	version := endpoint.Version
+	if version == "" {
+		version = c.registry.Version
+	}
+
 	address := url.URL{
-		Scheme: APIProtocol,
-		Path:   fmt.Sprintf("%s/%s", APIVersion, endpoint.path),
-		Host:   APIAddress,
+		Scheme: base.Scheme,
+		Path:   fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(base.Path, "/"), version, endpoint.Path),
+		Host:   base.Host,
 	}
 
-	if endpoint.apiKeyRequired {
-		address.Host = fmt.Sprintf("%s.%s", c.apiKey, APIAddress)
+	if endpoint.APIKeyRequired && c.registry.Auth == KeyInSubdomain {
+		address.Host = c.registry.keyHost(c.apiKey, base.Host)
 	}
 
 	return address.String(), nil
 
 }
 
-func getEndpoint(name string) (*apiEndpoint, error) {
-	endpoint, ok := apiEndpoints[name]
-	if !ok {
-		return nil, fmt.Errorf("endpoint %s not found", name)
+func (c *Client) newRequest(ctx context.Context, method, address string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, address, body)
+	if err != nil {
+		return nil, err
 	}
 
-	return &endpoint, nil
+	req.Header.Set("User-Agent", c.userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	return req, nil
 }
 
-func getResponseBodyAsString(response *http.Response) (string, error) {
+func readResponse(response *http.Response, endpointPath string) (*apiResponse, error) {
 	res, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return string(res), nil
+	return &apiResponse{
+		endpoint:   endpointPath,
+		statusCode: response.StatusCode,
+		header:     response.Header,
+		body:       string(res),
+	}, nil
 }
 
 func (o *Options) parse() (*url.Values, error) {
 	if o.UserIP == "" {
-		return nil, errors.New("filed UserIP can not be empty, it is required")
+		return nil, ErrMissingUserIP
 	}
 
 	if o.UserAgent == "" {
-		return nil, errors.New("filed UserAgent can not be empty, it is required")
+		return nil, ErrMissingUserAgent
 	}
 
 	v := url.Values{}
@@ -244,19 +529,19 @@ func (o *Options) parse() (*url.Values, error) {
 	}
 
 	if o.Created != "" {
-		created, err := time.Parse(DateFormat, o.Created)
+		created, err := unixTime(o.Created)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: Created %q is neither RFC 3339 date nor Unix timestamp", ErrInvalidParameter, o.Created)
 		}
-		v.Add("comment_date_gmt", fmt.Sprint(created.Unix()))
+		v.Add("comment_date_gmt", created)
 	}
 
 	if o.Modified != "" {
-		modified, err := time.Parse(DateFormat, o.Modified)
+		modified, err := unixTime(o.Modified)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: Modified %q is neither RFC 3339 date nor Unix timestamp", ErrInvalidParameter, o.Modified)
 		}
-		v.Add("comment_post_modified_gmt", fmt.Sprint(modified.Unix()))
+		v.Add("comment_post_modified_gmt", modified)
 	}
 
 	if o.Lang != "" {
@@ -275,9 +560,50 @@ func (o *Options) parse() (*url.Values, error) {
 		v.Add("is_test", o.IsTest)
 	}
 
+	if o.CommentType != "" {
+		v.Add("comment_type", string(o.CommentType))
+	}
+
+	if o.HoneypotFieldName != "" {
+		if reservedParams[o.HoneypotFieldName] || strings.HasPrefix(o.HoneypotFieldName, "comment_") {
+			return nil, fmt.Errorf("%w: honeypot field %q is Akismet parameter", ErrInvalidParameter, o.HoneypotFieldName)
+		}
+		v.Add("honeypot_field_name", o.HoneypotFieldName)
+		v.Add(o.HoneypotFieldName, o.HoneypotValue)
+	}
+
+	if o.RecheckReason != "" {
+		v.Add("recheck_reason", o.RecheckReason)
+	}
+
+	for _, c := range o.CommentContext {
+		v.Add("comment_context[]", c)
+	}
+
+	if o.CommentParent != "" {
+		v.Add("comment_parent", o.CommentParent)
+	}
+
+	for name, value := range o.ServerEnv {
+		if !serverEnvPattern.MatchString(name) {
+			return nil, fmt.Errorf("%w: server env %q is not upper case variable name", ErrInvalidParameter, name)
+		}
+		v.Add(name, value)
+	}
+
 	return &v, nil
 }
 
-func internalError() error {
-	return errors.New("internal error")
+// unixTime converts date in RFC 3339 format or Unix timestamp to Unix timestamp
+func unixTime(value string) (string, error) {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return value, nil
+	}
+
+	t, err := time.Parse(DateFormat, value)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(t.Unix(), 10), nil
 }
@@ -1,17 +1,25 @@
 package akismet
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestMain(m *testing.M) {
-	apiEndpoints["withOutKey"] = apiEndpoint{"without-key", "GET", false}
-	apiEndpoints["withKey"] = apiEndpoint{"with-key", "GET", true}
+	apiEndpoints["withOutKey"] = Endpoint{"without-key", "GET", false, APIVersion}
+	apiEndpoints["withKey"] = Endpoint{"with-key", "GET", true, APIVersion}
 
 	os.Exit(m.Run())
 }
@@ -72,6 +80,55 @@ func TestVeryfiClient(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (b *closeRecorder) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestVerifyKey(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	body := &closeRecorder{Reader: strings.NewReader("valid")}
+	httpmock.RegisterResponder("GET", "https://rest.akismet.com/1.1/verify-key?blog=test_site&key=test_api_key", func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Body: body, Header: http.Header{}}, nil
+	})
+
+	client := NewClient("test_api_key", "test_site")
+	err := client.VerifyKey(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, body.closed)
+}
+
+func TestVerifyKeyNotValid(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://rest.akismet.com/1.1/verify-key?blog=test_site&key=test_api_key", func(req *http.Request) (*http.Response, error) {
+		res := httpmock.NewStringResponse(200, "invalid")
+		res.Header.Set("X-akismet-debug-help", "We were unable to parse your blog URI")
+		return res, nil
+	})
+
+	client := NewClient("test_api_key", "test_site")
+	err := client.VerifyKey(context.Background())
+	assert.True(t, errors.Is(err, ErrInvalidKey))
+	assert.Contains(t, err.Error(), "We were unable to parse your blog URI")
+}
+
+func TestVerifyKeyTransportError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := NewClient("test_api_key", "test_site")
+	err := client.VerifyKey(context.Background())
+	assert.True(t, errors.Is(err, httpmock.NoResponderFound))
+}
+
 func TestParseOptions(t *testing.T) {
 	options := Options{
 		UserIP:      "",
@@ -192,6 +249,125 @@ func TestParseOptions(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestParseOptionsExtended(t *testing.T) {
+	options := Options{
+		UserIP:            "127.0.0.1",
+		UserAgent:         "TestUserAgent",
+		CommentType:       CommentTypeSignup,
+		HoneypotFieldName: "hidden_field",
+		HoneypotValue:     "filled by bot",
+		RecheckReason:     "edit",
+		CommentContext:    []string{"cooking", "recipes"},
+		CommentParent:     "42",
+		ServerEnv:         map[string]string{"HTTP_ACCEPT": "text/html", "SERVER_NAME": "example.com"},
+	}
+
+	r, err := options.parse()
+	assert.Nil(t, err)
+	expected := url.Values{}
+	expected.Add("user_ip", "127.0.0.1")
+	expected.Add("user_agent", "TestUserAgent")
+	expected.Add("comment_type", "signup")
+	expected.Add("honeypot_field_name", "hidden_field")
+	expected.Add("hidden_field", "filled by bot")
+	expected.Add("recheck_reason", "edit")
+	expected.Add("comment_context[]", "cooking")
+	expected.Add("comment_context[]", "recipes")
+	expected.Add("comment_parent", "42")
+	expected.Add("HTTP_ACCEPT", "text/html")
+	expected.Add("SERVER_NAME", "example.com")
+	assert.Equal(t, expected, *r)
+
+	options.HoneypotValue = ""
+	r, err = options.parse()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{""}, (*r)["hidden_field"])
+}
+
+func TestParseOptionsParameterCollision(t *testing.T) {
+	for _, env := range []map[string]string{
+		{"user_ip": "9.9.9.9"},
+		{"api_key": "evil"},
+		{"comment_content": "spoofed"},
+		{"HTTP-ACCEPT": "text/html"},
+	} {
+		options := Options{UserIP: "1.1.1.1", UserAgent: "TestUserAgent", ServerEnv: env}
+		_, err := options.parse()
+		assert.True(t, errors.Is(err, ErrInvalidParameter), "%v", env)
+	}
+
+	for _, field := range []string{"user_ip", "key", "blog", "comment_author"} {
+		options := Options{UserIP: "1.1.1.1", UserAgent: "TestUserAgent", HoneypotFieldName: field, HoneypotValue: "x"}
+		_, err := options.parse()
+		assert.True(t, errors.Is(err, ErrInvalidParameter), field)
+	}
+
+	for _, options := range []Options{
+		{UserIP: "1.1.1.1", UserAgent: "TestUserAgent", Created: "yesterday"},
+		{UserIP: "1.1.1.1", UserAgent: "TestUserAgent", Modified: "2015-13-01"},
+	} {
+		_, err := options.parse()
+		assert.True(t, errors.Is(err, ErrInvalidParameter), "%v", err)
+	}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	client := NewClient("test_api_key", "http://example.com")
+	_, err := client.Check(Options{UserIP: "1.1.1.1", UserAgent: "TestUserAgent", ServerEnv: map[string]string{"api_key": "evil"}})
+	assert.True(t, errors.Is(err, ErrInvalidParameter))
+}
+
+func TestOptionsJSON(t *testing.T) {
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent", Content: "Hello", CommentContext: []string{"cooking"}}
+	assert.Nil(t, options.SetCreated(time.Date(2012, 11, 1, 22, 8, 41, 0, time.UTC)))
+	data, err := json.Marshal(options)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"user_ip":"127.0.0.1","user_agent":"TestUserAgent","comment_content":"Hello","comment_date_gmt":"2012-11-01T22:08:41Z","comment_context":["cooking"]}`, string(data))
+
+	var decoded Options
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, options, decoded)
+
+	decoded = Options{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"user_ip":"127.0.0.1","user_agent":"TestUserAgent","comment_date_gmt":"1351807721","comment_post_modified_gmt":"2017-11-01T22:08:41Z"}`), &decoded))
+	r, err := decoded.parse()
+	assert.Nil(t, err)
+	assert.Equal(t, "1351807721", r.Get("comment_date_gmt"))
+	assert.Equal(t, "1509574121", r.Get("comment_post_modified_gmt"))
+}
+
+func TestOptionsSetters(t *testing.T) {
+	options := Options{UserAgent: "TestUserAgent"}
+	assert.Nil(t, options.SetUserIP(net.ParseIP("2001:db8::1")))
+	assert.Equal(t, "2001:db8::1", options.UserIP)
+	assert.Nil(t, options.SetUserIP(net.ParseIP("127.0.0.1")))
+	assert.Equal(t, "127.0.0.1", options.UserIP)
+	assert.Error(t, options.SetUserIP(nil))
+	assert.Error(t, options.SetUserIP(net.IP{1, 2, 3}))
+	assert.Equal(t, "127.0.0.1", options.UserIP)
+
+	assert.Nil(t, options.SetCreated(time.Date(2012, 11, 1, 22, 8, 41, 0, time.UTC)))
+	assert.Nil(t, options.SetModified(time.Date(2017, 11, 1, 23, 8, 41, 0, time.FixedZone("CET", 3600))))
+	for _, invalid := range []time.Time{{}, time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC)} {
+		assert.Error(t, options.SetCreated(invalid))
+		assert.Error(t, options.SetModified(invalid))
+	}
+	options.SetTest(true)
+	assert.Nil(t, options.SetLang("en", "fr_ca"))
+	assert.Error(t, options.SetLang("en, fr"))
+	assert.Equal(t, "en, fr_ca", options.Lang)
+
+	r, err := options.parse()
+	assert.Nil(t, err)
+	assert.Equal(t, "1351807721", r.Get("comment_date_gmt"))
+	assert.Equal(t, "1509574121", r.Get("comment_post_modified_gmt"))
+	assert.Equal(t, "true", r.Get("is_test"))
+	assert.Equal(t, "en, fr_ca", r.Get("blog_lang"))
+
+	options.SetTest(false)
+	assert.Empty(t, options.IsTest)
+}
+
 func TestIsSpamMissingRequiredOptions(t *testing.T) {
 	client := NewClient("test_api_key", "test_site")
 	options := Options{}
@@ -206,7 +382,7 @@ func TestIsSpamMissingRequiredOptions(t *testing.T) {
 func TestIsSpamInternal(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
-	httpmock.RegisterResponder("GET", "https://test_api_key.rest.akismet.com/1.1/comment-check?blog=test_site&user_agent=TestUserAgent&user_ip=127.0.0.1", httpmock.NewStringResponder(500, ""))
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/comment-check", httpmock.NewStringResponder(500, ""))
 
 	client := NewClient("test_api_key", "test_site")
 	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
@@ -217,7 +393,7 @@ func TestIsSpamInternal(t *testing.T) {
 func TestIsSpamTrue(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
-	httpmock.RegisterResponder("GET", "https://test_api_key.rest.akismet.com/1.1/comment-check?blog=test_site&user_agent=TestUserAgent&user_ip=127.0.0.1", httpmock.NewStringResponder(200, "true"))
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/comment-check", httpmock.NewStringResponder(200, "true"))
 
 	client := NewClient("test_api_key", "test_site")
 	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
@@ -229,7 +405,7 @@ func TestIsSpamTrue(t *testing.T) {
 func TestIsSpamInvalid(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
-	httpmock.RegisterResponder("GET", "https://test_api_key.rest.akismet.com/1.1/comment-check?blog=test_site&user_agent=TestUserAgent&user_ip=127.0.0.1", httpmock.NewStringResponder(200, "invalid"))
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/comment-check", httpmock.NewStringResponder(200, "invalid"))
 
 	client := NewClient("test_api_key", "test_site")
 	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
@@ -241,7 +417,7 @@ func TestIsSpamInvalid(t *testing.T) {
 func TestIsSpamFalse(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
-	httpmock.RegisterResponder("GET", "https://test_api_key.rest.akismet.com/1.1/comment-check?blog=test_site&user_agent=TestUserAgent&user_ip=127.0.0.1", httpmock.NewStringResponder(200, "false"))
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/comment-check", httpmock.NewStringResponder(200, "false"))
 
 	client := NewClient("test_api_key", "test_site")
 	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
@@ -264,7 +440,7 @@ func TestSubmitSpamMissingRequiredOptions(t *testing.T) {
 func TestSubmitSpamInternal(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
-	httpmock.RegisterResponder("GET", "https://test_api_key.rest.akismet.com/1.1/submit-spam?blog=test_site&user_agent=TestUserAgent&user_ip=127.0.0.1", httpmock.NewStringResponder(500, ""))
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/submit-spam", httpmock.NewStringResponder(500, ""))
 
 	client := NewClient("test_api_key", "test_site")
 	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
@@ -275,7 +451,7 @@ func TestSubmitSpamInternal(t *testing.T) {
 func TestSubmitSpamTrue(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
-	httpmock.RegisterResponder("GET", "https://test_api_key.rest.akismet.com/1.1/submit-spam?blog=test_site&user_agent=TestUserAgent&user_ip=127.0.0.1", httpmock.NewStringResponder(200, "Thanks for making the web a better place."))
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/submit-spam", httpmock.NewStringResponder(200, "Thanks for making the web a better place."))
 
 	client := NewClient("test_api_key", "test_site")
 	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
@@ -286,7 +462,7 @@ func TestSubmitSpamTrue(t *testing.T) {
 func TestSpamSpamInvalid(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
-	httpmock.RegisterResponder("GET", "https://test_api_key.rest.akismet.com/1.1/submit-spam?blog=test_site&user_agent=TestUserAgent&user_ip=127.0.0.1", httpmock.NewStringResponder(200, "invalid"))
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/submit-spam", httpmock.NewStringResponder(200, "invalid"))
 
 	client := NewClient("test_api_key", "test_site")
 	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
@@ -308,7 +484,7 @@ func TestSubmitHamMissingRequiredOptions(t *testing.T) {
 func TestSubmitHamInternal(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
-	httpmock.RegisterResponder("GET", "https://test_api_key.rest.akismet.com/1.1/submit-ham?blog=test_site&user_agent=TestUserAgent&user_ip=127.0.0.1", httpmock.NewStringResponder(500, ""))
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/submit-ham", httpmock.NewStringResponder(500, ""))
 
 	client := NewClient("test_api_key", "test_site")
 	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
@@ -319,7 +495,7 @@ func TestSubmitHamInternal(t *testing.T) {
 func TestSubmitHamTrue(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
-	httpmock.RegisterResponder("GET", "https://test_api_key.rest.akismet.com/1.1/submit-ham?blog=test_site&user_agent=TestUserAgent&user_ip=127.0.0.1", httpmock.NewStringResponder(200, "Thanks for making the web a better place."))
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/submit-ham", httpmock.NewStringResponder(200, "Thanks for making the web a better place."))
 
 	client := NewClient("test_api_key", "test_site")
 	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
@@ -330,7 +506,7 @@ func TestSubmitHamTrue(t *testing.T) {
 func TestSpamHamInvalid(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
-	httpmock.RegisterResponder("GET", "https://test_api_key.rest.akismet.com/1.1/submit-ham?blog=test_site&user_agent=TestUserAgent&user_ip=127.0.0.1", httpmock.NewStringResponder(200, "invalid"))
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/submit-ham", httpmock.NewStringResponder(200, "invalid"))
 
 	client := NewClient("test_api_key", "test_site")
 	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
@@ -338,12 +514,136 @@ func TestSpamHamInvalid(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func blockingResponder(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestVeryfiClientContextCanceled(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://rest.akismet.com/1.1/verify-key?blog=test_site&key=test_api_key", blockingResponder)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClient("test_api_key", "test_site")
+	err := client.VeryfiClientContext(ctx)
+	assert.Error(t, err)
+}
+
+func TestIsSpamContextDeadline(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/comment-check", blockingResponder)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	client := NewClient("test_api_key", "test_site")
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	res, err := client.IsSpamContext(ctx, options)
+	assert.Error(t, err)
+	assert.False(t, res)
+	assert.Equal(t, context.DeadlineExceeded, ctx.Err())
+}
+
+func TestSubmitSpamContextCanceled(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/submit-spam", blockingResponder)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClient("test_api_key", "test_site")
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	err := client.SubmitSpamContext(ctx, options)
+	assert.Error(t, err)
+}
+
+func TestSubmitHamContext(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/submit-ham", httpmock.NewStringResponder(200, "Thanks for making the web a better place."))
+
+	client := NewClient("test_api_key", "test_site")
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	err := client.SubmitHamContext(context.Background(), options)
+	assert.Nil(t, err)
+}
+
+func TestMakeRequestSendsPostForm(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var received *http.Request
+	var body url.Values
+	for _, path := range []string{"comment-check", "submit-spam", "submit-ham"} {
+		httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/"+path, func(req *http.Request) (*http.Response, error) {
+			received = req
+			req.ParseForm()
+			body = req.PostForm
+			if path == "comment-check" {
+				return httpmock.NewStringResponse(200, "false"), nil
+			}
+			return httpmock.NewStringResponse(200, "Thanks for making the web a better place."), nil
+		})
+	}
+
+	client := NewClient("test_api_key", "test_site")
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent", Content: "Some long comment & more"}
+	expected := url.Values{}
+	expected.Add("user_ip", "127.0.0.1")
+	expected.Add("user_agent", "TestUserAgent")
+	expected.Add("comment_content", "Some long comment & more")
+	expected.Add("blog", "test_site")
+
+	for _, call := range []func(Options) error{
+		func(o Options) error { _, err := client.IsSpam(o); return err },
+		client.SubmitSpam,
+		client.SubmitHam,
+	} {
+		received, body = nil, nil
+		assert.Nil(t, call(options))
+		if assert.NotNil(t, received) {
+			assert.Equal(t, "POST", received.Method)
+			assert.Equal(t, "application/x-www-form-urlencoded", received.Header.Get("Content-Type"))
+			assert.Empty(t, received.URL.RawQuery)
+			assert.Equal(t, expected, body)
+		}
+	}
+}
+
+func TestMakeRequestFollowsGetMethod(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var received *http.Request
+	httpmock.RegisterResponder("GET", "https://test_api_key.rest.akismet.com/1.1/with-key", func(req *http.Request) (*http.Response, error) {
+		received = req
+		return httpmock.NewStringResponse(200, "ok"), nil
+	})
+
+	client := NewClient("test_api_key", "test_site")
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	r, err := client.makeRequest(context.Background(), options, "withKey")
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", r.body)
+	if assert.NotNil(t, received) {
+		assert.Equal(t, "GET", received.Method)
+		assert.Equal(t, "blog=test_site&user_agent=TestUserAgent&user_ip=127.0.0.1", received.URL.RawQuery)
+		assert.Nil(t, received.Body)
+	}
+}
+
 func TestVerifyClientEndpointWrongAddress(t *testing.T) {
-	apiEndpoints = map[string]apiEndpoint{
-		"verifyKey": apiEndpoint{
-			path:           ".../",
-			method:         "POST",
-			apiKeyRequired: false,
+	defer func(endpoints map[string]Endpoint) { apiEndpoints = endpoints }(apiEndpoints)
+	apiEndpoints = map[string]Endpoint{
+		"verifyKey": Endpoint{
+			Path:           ".../",
+			Method:         "POST",
+			APIKeyRequired: false,
 		},
 	}
 
@@ -354,7 +654,8 @@ func TestVerifyClientEndpointWrongAddress(t *testing.T) {
 }
 
 func TestVerifyClientWrongEndpoint(t *testing.T) {
-	apiEndpoints = map[string]apiEndpoint{}
+	defer func(endpoints map[string]Endpoint) { apiEndpoints = endpoints }(apiEndpoints)
+	apiEndpoints = map[string]Endpoint{}
 	client := NewClient("test_api_key", "test_site")
 	err := client.VeryfiClient()
 	assert.Error(t, err)
@@ -0,0 +1,1083 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: akismet.proto
+
+package akismetpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Verdict of comment-check, HOLD is returned only by circuit breaker fallback
+type Verdict int32
+
+const (
+	Verdict_VERDICT_UNSPECIFIED Verdict = 0
+	Verdict_VERDICT_HAM         Verdict = 1
+	Verdict_VERDICT_SPAM        Verdict = 2
+	Verdict_VERDICT_DISCARD     Verdict = 3
+	Verdict_VERDICT_HOLD        Verdict = 4
+)
+
+// Enum value maps for Verdict.
+var (
+	Verdict_name = map[int32]string{
+		0: "VERDICT_UNSPECIFIED",
+		1: "VERDICT_HAM",
+		2: "VERDICT_SPAM",
+		3: "VERDICT_DISCARD",
+		4: "VERDICT_HOLD",
+	}
+	Verdict_value = map[string]int32{
+		"VERDICT_UNSPECIFIED": 0,
+		"VERDICT_HAM":         1,
+		"VERDICT_SPAM":        2,
+		"VERDICT_DISCARD":     3,
+		"VERDICT_HOLD":        4,
+	}
+)
+
+func (x Verdict) Enum() *Verdict {
+	p := new(Verdict)
+	*p = x
+	return p
+}
+
+func (x Verdict) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Verdict) Descriptor() protoreflect.EnumDescriptor {
+	return file_akismet_proto_enumTypes[0].Descriptor()
+}
+
+func (Verdict) Type() protoreflect.EnumType {
+	return &file_akismet_proto_enumTypes[0]
+}
+
+func (x Verdict) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Verdict.Descriptor instead.
+func (Verdict) EnumDescriptor() ([]byte, []int) {
+	return file_akismet_proto_rawDescGZIP(), []int{0}
+}
+
+// Options describes checked content, fields follow Akismet API parameters
+type Options struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	UserIp              string                 `protobuf:"bytes,1,opt,name=user_ip,json=userIp,proto3" json:"user_ip,omitempty"`
+	UserAgent           string                 `protobuf:"bytes,2,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Referrer            string                 `protobuf:"bytes,3,opt,name=referrer,proto3" json:"referrer,omitempty"`
+	Permalink           string                 `protobuf:"bytes,4,opt,name=permalink,proto3" json:"permalink,omitempty"`
+	CommentType         string                 `protobuf:"bytes,5,opt,name=comment_type,json=commentType,proto3" json:"comment_type,omitempty"`
+	CommentAuthor       string                 `protobuf:"bytes,6,opt,name=comment_author,json=commentAuthor,proto3" json:"comment_author,omitempty"`
+	CommentAuthorEmail  string                 `protobuf:"bytes,7,opt,name=comment_author_email,json=commentAuthorEmail,proto3" json:"comment_author_email,omitempty"`
+	CommentAuthorUrl    string                 `protobuf:"bytes,8,opt,name=comment_author_url,json=commentAuthorUrl,proto3" json:"comment_author_url,omitempty"`
+	CommentContent      string                 `protobuf:"bytes,9,opt,name=comment_content,json=commentContent,proto3" json:"comment_content,omitempty"`
+	CommentDate         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=comment_date,json=commentDate,proto3" json:"comment_date,omitempty"`
+	CommentPostModified *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=comment_post_modified,json=commentPostModified,proto3" json:"comment_post_modified,omitempty"`
+	BlogLang            string                 `protobuf:"bytes,12,opt,name=blog_lang,json=blogLang,proto3" json:"blog_lang,omitempty"`
+	BlogCharset         string                 `protobuf:"bytes,13,opt,name=blog_charset,json=blogCharset,proto3" json:"blog_charset,omitempty"`
+	UserRole            string                 `protobuf:"bytes,14,opt,name=user_role,json=userRole,proto3" json:"user_role,omitempty"`
+	IsTest              bool                   `protobuf:"varint,15,opt,name=is_test,json=isTest,proto3" json:"is_test,omitempty"`
+	HoneypotFieldName   string                 `protobuf:"bytes,16,opt,name=honeypot_field_name,json=honeypotFieldName,proto3" json:"honeypot_field_name,omitempty"`
+	HoneypotValue       string                 `protobuf:"bytes,17,opt,name=honeypot_value,json=honeypotValue,proto3" json:"honeypot_value,omitempty"`
+	RecheckReason       string                 `protobuf:"bytes,18,opt,name=recheck_reason,json=recheckReason,proto3" json:"recheck_reason,omitempty"`
+	CommentContext      []string               `protobuf:"bytes,19,rep,name=comment_context,json=commentContext,proto3" json:"comment_context,omitempty"`
+	CommentParent       string                 `protobuf:"bytes,20,opt,name=comment_parent,json=commentParent,proto3" json:"comment_parent,omitempty"`
+	ServerEnv           map[string]string      `protobuf:"bytes,21,rep,name=server_env,json=serverEnv,proto3" json:"server_env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Options) Reset() {
+	*x = Options{}
+	mi := &file_akismet_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Options) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Options) ProtoMessage() {}
+
+func (x *Options) ProtoReflect() protoreflect.Message {
+	mi := &file_akismet_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Options.ProtoReflect.Descriptor instead.
+func (*Options) Descriptor() ([]byte, []int) {
+	return file_akismet_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Options) GetUserIp() string {
+	if x != nil {
+		return x.UserIp
+	}
+	return ""
+}
+
+func (x *Options) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Options) GetReferrer() string {
+	if x != nil {
+		return x.Referrer
+	}
+	return ""
+}
+
+func (x *Options) GetPermalink() string {
+	if x != nil {
+		return x.Permalink
+	}
+	return ""
+}
+
+func (x *Options) GetCommentType() string {
+	if x != nil {
+		return x.CommentType
+	}
+	return ""
+}
+
+func (x *Options) GetCommentAuthor() string {
+	if x != nil {
+		return x.CommentAuthor
+	}
+	return ""
+}
+
+func (x *Options) GetCommentAuthorEmail() string {
+	if x != nil {
+		return x.CommentAuthorEmail
+	}
+	return ""
+}
+
+func (x *Options) GetCommentAuthorUrl() string {
+	if x != nil {
+		return x.CommentAuthorUrl
+	}
+	return ""
+}
+
+func (x *Options) GetCommentContent() string {
+	if x != nil {
+		return x.CommentContent
+	}
+	return ""
+}
+
+func (x *Options) GetCommentDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CommentDate
+	}
+	return nil
+}
+
+func (x *Options) GetCommentPostModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CommentPostModified
+	}
+	return nil
+}
+
+func (x *Options) GetBlogLang() string {
+	if x != nil {
+		return x.BlogLang
+	}
+	return ""
+}
+
+func (x *Options) GetBlogCharset() string {
+	if x != nil {
+		return x.BlogCharset
+	}
+	return ""
+}
+
+func (x *Options) GetUserRole() string {
+	if x != nil {
+		return x.UserRole
+	}
+	return ""
+}
+
+func (x *Options) GetIsTest() bool {
+	if x != nil {
+		return x.IsTest
+	}
+	return false
+}
+
+func (x *Options) GetHoneypotFieldName() string {
+	if x != nil {
+		return x.HoneypotFieldName
+	}
+	return ""
+}
+
+func (x *Options) GetHoneypotValue() string {
+	if x != nil {
+		return x.HoneypotValue
+	}
+	return ""
+}
+
+func (x *Options) GetRecheckReason() string {
+	if x != nil {
+		return x.RecheckReason
+	}
+	return ""
+}
+
+func (x *Options) GetCommentContext() []string {
+	if x != nil {
+		return x.CommentContext
+	}
+	return nil
+}
+
+func (x *Options) GetCommentParent() string {
+	if x != nil {
+		return x.CommentParent
+	}
+	return ""
+}
+
+func (x *Options) GetServerEnv() map[string]string {
+	if x != nil {
+		return x.ServerEnv
+	}
+	return nil
+}
+
+// CheckResult contains verdict and metadata sent by Akismet
+type CheckResult struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Verdict   Verdict                `protobuf:"varint,1,opt,name=verdict,proto3,enum=akismet.v1.Verdict" json:"verdict,omitempty"`
+	ProTip    string                 `protobuf:"bytes,2,opt,name=pro_tip,json=proTip,proto3" json:"pro_tip,omitempty"`
+	Guid      string                 `protobuf:"bytes,3,opt,name=guid,proto3" json:"guid,omitempty"`
+	DebugHelp string                 `protobuf:"bytes,4,opt,name=debug_help,json=debugHelp,proto3" json:"debug_help,omitempty"`
+	AlertCode string                 `protobuf:"bytes,5,opt,name=alert_code,json=alertCode,proto3" json:"alert_code,omitempty"`
+	AlertMsg  string                 `protobuf:"bytes,6,opt,name=alert_msg,json=alertMsg,proto3" json:"alert_msg,omitempty"`
+	// fallback is true when verdict comes from circuit breaker policy, not from Akismet
+	Fallback bool `protobuf:"varint,7,opt,name=fallback,proto3" json:"fallback,omitempty"`
+	// cache_hit is true when result comes from cache
+	CacheHit      bool `protobuf:"varint,8,opt,name=cache_hit,json=cacheHit,proto3" json:"cache_hit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResult) Reset() {
+	*x = CheckResult{}
+	mi := &file_akismet_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResult) ProtoMessage() {}
+
+func (x *CheckResult) ProtoReflect() protoreflect.Message {
+	mi := &file_akismet_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResult.ProtoReflect.Descriptor instead.
+func (*CheckResult) Descriptor() ([]byte, []int) {
+	return file_akismet_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CheckResult) GetVerdict() Verdict {
+	if x != nil {
+		return x.Verdict
+	}
+	return Verdict_VERDICT_UNSPECIFIED
+}
+
+func (x *CheckResult) GetProTip() string {
+	if x != nil {
+		return x.ProTip
+	}
+	return ""
+}
+
+func (x *CheckResult) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+func (x *CheckResult) GetDebugHelp() string {
+	if x != nil {
+		return x.DebugHelp
+	}
+	return ""
+}
+
+func (x *CheckResult) GetAlertCode() string {
+	if x != nil {
+		return x.AlertCode
+	}
+	return ""
+}
+
+func (x *CheckResult) GetAlertMsg() string {
+	if x != nil {
+		return x.AlertMsg
+	}
+	return ""
+}
+
+func (x *CheckResult) GetFallback() bool {
+	if x != nil {
+		return x.Fallback
+	}
+	return false
+}
+
+func (x *CheckResult) GetCacheHit() bool {
+	if x != nil {
+		return x.CacheHit
+	}
+	return false
+}
+
+// Error describes failure of single item of a stream, code is gRPC status code
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_akismet_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_akismet_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_akismet_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Error) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type VerifyKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyKeyRequest) Reset() {
+	*x = VerifyKeyRequest{}
+	mi := &file_akismet_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyKeyRequest) ProtoMessage() {}
+
+func (x *VerifyKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akismet_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyKeyRequest.ProtoReflect.Descriptor instead.
+func (*VerifyKeyRequest) Descriptor() ([]byte, []int) {
+	return file_akismet_proto_rawDescGZIP(), []int{3}
+}
+
+type VerifyKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyKeyResponse) Reset() {
+	*x = VerifyKeyResponse{}
+	mi := &file_akismet_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyKeyResponse) ProtoMessage() {}
+
+func (x *VerifyKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akismet_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyKeyResponse.ProtoReflect.Descriptor instead.
+func (*VerifyKeyResponse) Descriptor() ([]byte, []int) {
+	return file_akismet_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VerifyKeyResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type CheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *Options               `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckRequest) Reset() {
+	*x = CheckRequest{}
+	mi := &file_akismet_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckRequest) ProtoMessage() {}
+
+func (x *CheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akismet_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckRequest.ProtoReflect.Descriptor instead.
+func (*CheckRequest) Descriptor() ([]byte, []int) {
+	return file_akismet_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CheckRequest) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type CheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *CheckResult           `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResponse) Reset() {
+	*x = CheckResponse{}
+	mi := &file_akismet_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResponse) ProtoMessage() {}
+
+func (x *CheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akismet_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResponse.ProtoReflect.Descriptor instead.
+func (*CheckResponse) Descriptor() ([]byte, []int) {
+	return file_akismet_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CheckResponse) GetResult() *CheckResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type SubmitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *Options               `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitRequest) Reset() {
+	*x = SubmitRequest{}
+	mi := &file_akismet_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitRequest) ProtoMessage() {}
+
+func (x *SubmitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akismet_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitRequest.ProtoReflect.Descriptor instead.
+func (*SubmitRequest) Descriptor() ([]byte, []int) {
+	return file_akismet_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SubmitRequest) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type SubmitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitResponse) Reset() {
+	*x = SubmitResponse{}
+	mi := &file_akismet_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitResponse) ProtoMessage() {}
+
+func (x *SubmitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akismet_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitResponse.ProtoReflect.Descriptor instead.
+func (*SubmitResponse) Descriptor() ([]byte, []int) {
+	return file_akismet_proto_rawDescGZIP(), []int{8}
+}
+
+type CheckBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       []*Options             `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckBatchRequest) Reset() {
+	*x = CheckBatchRequest{}
+	mi := &file_akismet_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckBatchRequest) ProtoMessage() {}
+
+func (x *CheckBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akismet_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckBatchRequest.ProtoReflect.Descriptor instead.
+func (*CheckBatchRequest) Descriptor() ([]byte, []int) {
+	return file_akismet_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CheckBatchRequest) GetOptions() []*Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type CheckBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// index is position of the item in CheckBatchRequest.options
+	Index         int32        `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Result        *CheckResult `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	Error         *Error       `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckBatchResponse) Reset() {
+	*x = CheckBatchResponse{}
+	mi := &file_akismet_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckBatchResponse) ProtoMessage() {}
+
+func (x *CheckBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akismet_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckBatchResponse.ProtoReflect.Descriptor instead.
+func (*CheckBatchResponse) Descriptor() ([]byte, []int) {
+	return file_akismet_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CheckBatchResponse) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *CheckBatchResponse) GetResult() *CheckResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *CheckBatchResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type CheckStreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is copied to response, use it to match responses with requests
+	Id            string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Options       *Options `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckStreamRequest) Reset() {
+	*x = CheckStreamRequest{}
+	mi := &file_akismet_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckStreamRequest) ProtoMessage() {}
+
+func (x *CheckStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_akismet_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckStreamRequest.ProtoReflect.Descriptor instead.
+func (*CheckStreamRequest) Descriptor() ([]byte, []int) {
+	return file_akismet_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CheckStreamRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CheckStreamRequest) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type CheckStreamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Result        *CheckResult           `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckStreamResponse) Reset() {
+	*x = CheckStreamResponse{}
+	mi := &file_akismet_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckStreamResponse) ProtoMessage() {}
+
+func (x *CheckStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_akismet_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckStreamResponse.ProtoReflect.Descriptor instead.
+func (*CheckStreamResponse) Descriptor() ([]byte, []int) {
+	return file_akismet_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CheckStreamResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CheckStreamResponse) GetResult() *CheckResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *CheckStreamResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+var File_akismet_proto protoreflect.FileDescriptor
+
+const file_akismet_proto_rawDesc = "" +
+	"\n" +
+	"\rakismet.proto\x12\n" +
+	"akismet.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa2\a\n" +
+	"\aOptions\x12\x17\n" +
+	"\auser_ip\x18\x01 \x01(\tR\x06userIp\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x02 \x01(\tR\tuserAgent\x12\x1a\n" +
+	"\breferrer\x18\x03 \x01(\tR\breferrer\x12\x1c\n" +
+	"\tpermalink\x18\x04 \x01(\tR\tpermalink\x12!\n" +
+	"\fcomment_type\x18\x05 \x01(\tR\vcommentType\x12%\n" +
+	"\x0ecomment_author\x18\x06 \x01(\tR\rcommentAuthor\x120\n" +
+	"\x14comment_author_email\x18\a \x01(\tR\x12commentAuthorEmail\x12,\n" +
+	"\x12comment_author_url\x18\b \x01(\tR\x10commentAuthorUrl\x12'\n" +
+	"\x0fcomment_content\x18\t \x01(\tR\x0ecommentContent\x12=\n" +
+	"\fcomment_date\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vcommentDate\x12N\n" +
+	"\x15comment_post_modified\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x13commentPostModified\x12\x1b\n" +
+	"\tblog_lang\x18\f \x01(\tR\bblogLang\x12!\n" +
+	"\fblog_charset\x18\r \x01(\tR\vblogCharset\x12\x1b\n" +
+	"\tuser_role\x18\x0e \x01(\tR\buserRole\x12\x17\n" +
+	"\ais_test\x18\x0f \x01(\bR\x06isTest\x12.\n" +
+	"\x13honeypot_field_name\x18\x10 \x01(\tR\x11honeypotFieldName\x12%\n" +
+	"\x0ehoneypot_value\x18\x11 \x01(\tR\rhoneypotValue\x12%\n" +
+	"\x0erecheck_reason\x18\x12 \x01(\tR\rrecheckReason\x12'\n" +
+	"\x0fcomment_context\x18\x13 \x03(\tR\x0ecommentContext\x12%\n" +
+	"\x0ecomment_parent\x18\x14 \x01(\tR\rcommentParent\x12A\n" +
+	"\n" +
+	"server_env\x18\x15 \x03(\v2\".akismet.v1.Options.ServerEnvEntryR\tserverEnv\x1a<\n" +
+	"\x0eServerEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfd\x01\n" +
+	"\vCheckResult\x12-\n" +
+	"\averdict\x18\x01 \x01(\x0e2\x13.akismet.v1.VerdictR\averdict\x12\x17\n" +
+	"\apro_tip\x18\x02 \x01(\tR\x06proTip\x12\x12\n" +
+	"\x04guid\x18\x03 \x01(\tR\x04guid\x12\x1d\n" +
+	"\n" +
+	"debug_help\x18\x04 \x01(\tR\tdebugHelp\x12\x1d\n" +
+	"\n" +
+	"alert_code\x18\x05 \x01(\tR\talertCode\x12\x1b\n" +
+	"\talert_msg\x18\x06 \x01(\tR\balertMsg\x12\x1a\n" +
+	"\bfallback\x18\a \x01(\bR\bfallback\x12\x1b\n" +
+	"\tcache_hit\x18\b \x01(\bR\bcacheHit\"5\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x12\n" +
+	"\x10VerifyKeyRequest\")\n" +
+	"\x11VerifyKeyResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\"=\n" +
+	"\fCheckRequest\x12-\n" +
+	"\aoptions\x18\x01 \x01(\v2\x13.akismet.v1.OptionsR\aoptions\"@\n" +
+	"\rCheckResponse\x12/\n" +
+	"\x06result\x18\x01 \x01(\v2\x17.akismet.v1.CheckResultR\x06result\">\n" +
+	"\rSubmitRequest\x12-\n" +
+	"\aoptions\x18\x01 \x01(\v2\x13.akismet.v1.OptionsR\aoptions\"\x10\n" +
+	"\x0eSubmitResponse\"B\n" +
+	"\x11CheckBatchRequest\x12-\n" +
+	"\aoptions\x18\x01 \x03(\v2\x13.akismet.v1.OptionsR\aoptions\"\x84\x01\n" +
+	"\x12CheckBatchResponse\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12/\n" +
+	"\x06result\x18\x02 \x01(\v2\x17.akismet.v1.CheckResultR\x06result\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.akismet.v1.ErrorR\x05error\"S\n" +
+	"\x12CheckStreamRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
+	"\aoptions\x18\x02 \x01(\v2\x13.akismet.v1.OptionsR\aoptions\"\x7f\n" +
+	"\x13CheckStreamResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12/\n" +
+	"\x06result\x18\x02 \x01(\v2\x17.akismet.v1.CheckResultR\x06result\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.akismet.v1.ErrorR\x05error*l\n" +
+	"\aVerdict\x12\x17\n" +
+	"\x13VERDICT_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vVERDICT_HAM\x10\x01\x12\x10\n" +
+	"\fVERDICT_SPAM\x10\x02\x12\x13\n" +
+	"\x0fVERDICT_DISCARD\x10\x03\x12\x10\n" +
+	"\fVERDICT_HOLD\x10\x042\xbd\x03\n" +
+	"\aAkismet\x12H\n" +
+	"\tVerifyKey\x12\x1c.akismet.v1.VerifyKeyRequest\x1a\x1d.akismet.v1.VerifyKeyResponse\x12<\n" +
+	"\x05Check\x12\x18.akismet.v1.CheckRequest\x1a\x19.akismet.v1.CheckResponse\x12C\n" +
+	"\n" +
+	"SubmitSpam\x12\x19.akismet.v1.SubmitRequest\x1a\x1a.akismet.v1.SubmitResponse\x12B\n" +
+	"\tSubmitHam\x12\x19.akismet.v1.SubmitRequest\x1a\x1a.akismet.v1.SubmitResponse\x12M\n" +
+	"\n" +
+	"CheckBatch\x12\x1d.akismet.v1.CheckBatchRequest\x1a\x1e.akismet.v1.CheckBatchResponse0\x01\x12R\n" +
+	"\vCheckStream\x12\x1e.akismet.v1.CheckStreamRequest\x1a\x1f.akismet.v1.CheckStreamResponse(\x010\x01B0Z.github.com/SebastianCzoch/akismet-go/akismetpbb\x06proto3"
+
+var (
+	file_akismet_proto_rawDescOnce sync.Once
+	file_akismet_proto_rawDescData []byte
+)
+
+func file_akismet_proto_rawDescGZIP() []byte {
+	file_akismet_proto_rawDescOnce.Do(func() {
+		file_akismet_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_akismet_proto_rawDesc), len(file_akismet_proto_rawDesc)))
+	})
+	return file_akismet_proto_rawDescData
+}
+
+var file_akismet_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_akismet_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_akismet_proto_goTypes = []any{
+	(Verdict)(0),                  // 0: akismet.v1.Verdict
+	(*Options)(nil),               // 1: akismet.v1.Options
+	(*CheckResult)(nil),           // 2: akismet.v1.CheckResult
+	(*Error)(nil),                 // 3: akismet.v1.Error
+	(*VerifyKeyRequest)(nil),      // 4: akismet.v1.VerifyKeyRequest
+	(*VerifyKeyResponse)(nil),     // 5: akismet.v1.VerifyKeyResponse
+	(*CheckRequest)(nil),          // 6: akismet.v1.CheckRequest
+	(*CheckResponse)(nil),         // 7: akismet.v1.CheckResponse
+	(*SubmitRequest)(nil),         // 8: akismet.v1.SubmitRequest
+	(*SubmitResponse)(nil),        // 9: akismet.v1.SubmitResponse
+	(*CheckBatchRequest)(nil),     // 10: akismet.v1.CheckBatchRequest
+	(*CheckBatchResponse)(nil),    // 11: akismet.v1.CheckBatchResponse
+	(*CheckStreamRequest)(nil),    // 12: akismet.v1.CheckStreamRequest
+	(*CheckStreamResponse)(nil),   // 13: akismet.v1.CheckStreamResponse
+	nil,                           // 14: akismet.v1.Options.ServerEnvEntry
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+}
+var file_akismet_proto_depIdxs = []int32{
+	15, // 0: akismet.v1.Options.comment_date:type_name -> google.protobuf.Timestamp
+	15, // 1: akismet.v1.Options.comment_post_modified:type_name -> google.protobuf.Timestamp
+	14, // 2: akismet.v1.Options.server_env:type_name -> akismet.v1.Options.ServerEnvEntry
+	0,  // 3: akismet.v1.CheckResult.verdict:type_name -> akismet.v1.Verdict
+	1,  // 4: akismet.v1.CheckRequest.options:type_name -> akismet.v1.Options
+	2,  // 5: akismet.v1.CheckResponse.result:type_name -> akismet.v1.CheckResult
+	1,  // 6: akismet.v1.SubmitRequest.options:type_name -> akismet.v1.Options
+	1,  // 7: akismet.v1.CheckBatchRequest.options:type_name -> akismet.v1.Options
+	2,  // 8: akismet.v1.CheckBatchResponse.result:type_name -> akismet.v1.CheckResult
+	3,  // 9: akismet.v1.CheckBatchResponse.error:type_name -> akismet.v1.Error
+	1,  // 10: akismet.v1.CheckStreamRequest.options:type_name -> akismet.v1.Options
+	2,  // 11: akismet.v1.CheckStreamResponse.result:type_name -> akismet.v1.CheckResult
+	3,  // 12: akismet.v1.CheckStreamResponse.error:type_name -> akismet.v1.Error
+	4,  // 13: akismet.v1.Akismet.VerifyKey:input_type -> akismet.v1.VerifyKeyRequest
+	6,  // 14: akismet.v1.Akismet.Check:input_type -> akismet.v1.CheckRequest
+	8,  // 15: akismet.v1.Akismet.SubmitSpam:input_type -> akismet.v1.SubmitRequest
+	8,  // 16: akismet.v1.Akismet.SubmitHam:input_type -> akismet.v1.SubmitRequest
+	10, // 17: akismet.v1.Akismet.CheckBatch:input_type -> akismet.v1.CheckBatchRequest
+	12, // 18: akismet.v1.Akismet.CheckStream:input_type -> akismet.v1.CheckStreamRequest
+	5,  // 19: akismet.v1.Akismet.VerifyKey:output_type -> akismet.v1.VerifyKeyResponse
+	7,  // 20: akismet.v1.Akismet.Check:output_type -> akismet.v1.CheckResponse
+	9,  // 21: akismet.v1.Akismet.SubmitSpam:output_type -> akismet.v1.SubmitResponse
+	9,  // 22: akismet.v1.Akismet.SubmitHam:output_type -> akismet.v1.SubmitResponse
+	11, // 23: akismet.v1.Akismet.CheckBatch:output_type -> akismet.v1.CheckBatchResponse
+	13, // 24: akismet.v1.Akismet.CheckStream:output_type -> akismet.v1.CheckStreamResponse
+	19, // [19:25] is the sub-list for method output_type
+	13, // [13:19] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_akismet_proto_init() }
+func file_akismet_proto_init() {
+	if File_akismet_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_akismet_proto_rawDesc), len(file_akismet_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_akismet_proto_goTypes,
+		DependencyIndexes: file_akismet_proto_depIdxs,
+		EnumInfos:         file_akismet_proto_enumTypes,
+		MessageInfos:      file_akismet_proto_msgTypes,
+	}.Build()
+	File_akismet_proto = out.File
+	file_akismet_proto_goTypes = nil
+	file_akismet_proto_depIdxs = nil
+}
@@ -0,0 +1,325 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: akismet.proto
+
+package akismetpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Akismet_VerifyKey_FullMethodName   = "/akismet.v1.Akismet/VerifyKey"
+	Akismet_Check_FullMethodName       = "/akismet.v1.Akismet/Check"
+	Akismet_SubmitSpam_FullMethodName  = "/akismet.v1.Akismet/SubmitSpam"
+	Akismet_SubmitHam_FullMethodName   = "/akismet.v1.Akismet/SubmitHam"
+	Akismet_CheckBatch_FullMethodName  = "/akismet.v1.Akismet/CheckBatch"
+	Akismet_CheckStream_FullMethodName = "/akismet.v1.Akismet/CheckStream"
+)
+
+// AkismetClient is the client API for Akismet service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Akismet checks content and submits corrections
+type AkismetClient interface {
+	// VerifyKey verifies API key and site configured in the server
+	VerifyKey(ctx context.Context, in *VerifyKeyRequest, opts ...grpc.CallOption) (*VerifyKeyResponse, error)
+	// Check checks single content
+	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+	// SubmitSpam submits spam which was not caught
+	SubmitSpam(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error)
+	// SubmitHam submits false positive
+	SubmitHam(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error)
+	// CheckBatch checks all passed contents concurrently, results are streamed in order of completion
+	CheckBatch(ctx context.Context, in *CheckBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CheckBatchResponse], error)
+	// CheckStream checks contents as they are sent, results are streamed in order of completion
+	CheckStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CheckStreamRequest, CheckStreamResponse], error)
+}
+
+type akismetClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAkismetClient(cc grpc.ClientConnInterface) AkismetClient {
+	return &akismetClient{cc}
+}
+
+func (c *akismetClient) VerifyKey(ctx context.Context, in *VerifyKeyRequest, opts ...grpc.CallOption) (*VerifyKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyKeyResponse)
+	err := c.cc.Invoke(ctx, Akismet_VerifyKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *akismetClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckResponse)
+	err := c.cc.Invoke(ctx, Akismet_Check_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *akismetClient) SubmitSpam(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitResponse)
+	err := c.cc.Invoke(ctx, Akismet_SubmitSpam_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *akismetClient) SubmitHam(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitResponse)
+	err := c.cc.Invoke(ctx, Akismet_SubmitHam_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *akismetClient) CheckBatch(ctx context.Context, in *CheckBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CheckBatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Akismet_ServiceDesc.Streams[0], Akismet_CheckBatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CheckBatchRequest, CheckBatchResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Akismet_CheckBatchClient = grpc.ServerStreamingClient[CheckBatchResponse]
+
+func (c *akismetClient) CheckStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CheckStreamRequest, CheckStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Akismet_ServiceDesc.Streams[1], Akismet_CheckStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CheckStreamRequest, CheckStreamResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Akismet_CheckStreamClient = grpc.BidiStreamingClient[CheckStreamRequest, CheckStreamResponse]
+
+// AkismetServer is the server API for Akismet service.
+// All implementations must embed UnimplementedAkismetServer
+// for forward compatibility.
+//
+// Akismet checks content and submits corrections
+type AkismetServer interface {
+	// VerifyKey verifies API key and site configured in the server
+	VerifyKey(context.Context, *VerifyKeyRequest) (*VerifyKeyResponse, error)
+	// Check checks single content
+	Check(context.Context, *CheckRequest) (*CheckResponse, error)
+	// SubmitSpam submits spam which was not caught
+	SubmitSpam(context.Context, *SubmitRequest) (*SubmitResponse, error)
+	// SubmitHam submits false positive
+	SubmitHam(context.Context, *SubmitRequest) (*SubmitResponse, error)
+	// CheckBatch checks all passed contents concurrently, results are streamed in order of completion
+	CheckBatch(*CheckBatchRequest, grpc.ServerStreamingServer[CheckBatchResponse]) error
+	// CheckStream checks contents as they are sent, results are streamed in order of completion
+	CheckStream(grpc.BidiStreamingServer[CheckStreamRequest, CheckStreamResponse]) error
+	mustEmbedUnimplementedAkismetServer()
+}
+
+// UnimplementedAkismetServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAkismetServer struct{}
+
+func (UnimplementedAkismetServer) VerifyKey(context.Context, *VerifyKeyRequest) (*VerifyKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyKey not implemented")
+}
+func (UnimplementedAkismetServer) Check(context.Context, *CheckRequest) (*CheckResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Check not implemented")
+}
+func (UnimplementedAkismetServer) SubmitSpam(context.Context, *SubmitRequest) (*SubmitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitSpam not implemented")
+}
+func (UnimplementedAkismetServer) SubmitHam(context.Context, *SubmitRequest) (*SubmitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitHam not implemented")
+}
+func (UnimplementedAkismetServer) CheckBatch(*CheckBatchRequest, grpc.ServerStreamingServer[CheckBatchResponse]) error {
+	return status.Error(codes.Unimplemented, "method CheckBatch not implemented")
+}
+func (UnimplementedAkismetServer) CheckStream(grpc.BidiStreamingServer[CheckStreamRequest, CheckStreamResponse]) error {
+	return status.Error(codes.Unimplemented, "method CheckStream not implemented")
+}
+func (UnimplementedAkismetServer) mustEmbedUnimplementedAkismetServer() {}
+func (UnimplementedAkismetServer) testEmbeddedByValue()                 {}
+
+// UnsafeAkismetServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AkismetServer will
+// result in compilation errors.
+type UnsafeAkismetServer interface {
+	mustEmbedUnimplementedAkismetServer()
+}
+
+func RegisterAkismetServer(s grpc.ServiceRegistrar, srv AkismetServer) {
+	// If the following call panics, it indicates UnimplementedAkismetServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Akismet_ServiceDesc, srv)
+}
+
+func _Akismet_VerifyKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AkismetServer).VerifyKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Akismet_VerifyKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AkismetServer).VerifyKey(ctx, req.(*VerifyKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Akismet_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AkismetServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Akismet_Check_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AkismetServer).Check(ctx, req.(*CheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Akismet_SubmitSpam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AkismetServer).SubmitSpam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Akismet_SubmitSpam_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AkismetServer).SubmitSpam(ctx, req.(*SubmitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Akismet_SubmitHam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AkismetServer).SubmitHam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Akismet_SubmitHam_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AkismetServer).SubmitHam(ctx, req.(*SubmitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Akismet_CheckBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CheckBatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AkismetServer).CheckBatch(m, &grpc.GenericServerStream[CheckBatchRequest, CheckBatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Akismet_CheckBatchServer = grpc.ServerStreamingServer[CheckBatchResponse]
+
+func _Akismet_CheckStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AkismetServer).CheckStream(&grpc.GenericServerStream[CheckStreamRequest, CheckStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Akismet_CheckStreamServer = grpc.BidiStreamingServer[CheckStreamRequest, CheckStreamResponse]
+
+// Akismet_ServiceDesc is the grpc.ServiceDesc for Akismet service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Akismet_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "akismet.v1.Akismet",
+	HandlerType: (*AkismetServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "VerifyKey",
+			Handler:    _Akismet_VerifyKey_Handler,
+		},
+		{
+			MethodName: "Check",
+			Handler:    _Akismet_Check_Handler,
+		},
+		{
+			MethodName: "SubmitSpam",
+			Handler:    _Akismet_SubmitSpam_Handler,
+		},
+		{
+			MethodName: "SubmitHam",
+			Handler:    _Akismet_SubmitHam_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CheckBatch",
+			Handler:       _Akismet_CheckBatch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CheckStream",
+			Handler:       _Akismet_CheckStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "akismet.proto",
+}
@@ -0,0 +1,5 @@
+// Package akismetpb contains protobuf schema of Akismet service and generated gRPC code,
+// package grpcakismet implements the service with akismet.Client
+package akismetpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative akismet.proto
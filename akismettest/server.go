@@ -0,0 +1,183 @@
+// Package akismettest provides local fake Akismet server for tests
+package akismettest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sync"
+)
+
+// Values which trigger documented Akismet test behaviour
+const (
+	SpamAuthor      = "viagra-test-123"
+	SpamAuthorEmail = "akismet-guaranteed-spam@example.com"
+	HamUserRole     = "administrator"
+)
+
+// SubmitResponse is a body of successful submit-spam and submit-ham response
+const SubmitResponse = "Thanks for making the web a better place."
+
+// Verdict is a comment-check result returned by Server
+type Verdict int
+
+// Possible verdicts
+const (
+	Ham Verdict = iota
+	Spam
+	Discard
+)
+
+// Request is a request received by Server
+type Request struct {
+	Endpoint string
+	Method   string
+	Header   http.Header
+	Form     url.Values
+}
+
+// Server is a fake Akismet API. Use its URL with akismet.WithBaseURL.
+type Server struct {
+	*httptest.Server
+	APIKey string
+
+	mu       sync.Mutex
+	verdicts []Verdict
+	requests []Request
+}
+
+// NewServer starts and returns new Server which accepts apiKey as a valid key.
+// Caller should call Close when finished.
+func NewServer(apiKey string) *Server {
+	s := &Server{APIKey: apiKey}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// QueueVerdicts makes next comment-check calls return passed verdicts in order,
+// then server falls back to test triggers
+func (s *Server) QueueVerdicts(verdicts ...Verdict) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verdicts = append(s.verdicts, verdicts...)
+}
+
+// Requests returns all requests received by Server
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// Reset forgets received requests and queued verdicts
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+	s.verdicts = nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	endpoint := path.Base(r.URL.Path)
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Endpoint: endpoint,
+		Method:   r.Method,
+		Header:   r.Header.Clone(),
+		Form:     r.Form,
+	})
+	s.mu.Unlock()
+
+	switch endpoint {
+	case "verify-key":
+		s.verifyKey(w, r.Form)
+	case "comment-check":
+		s.commentCheck(w, r.Form)
+	case "submit-spam", "submit-ham":
+		s.submit(w, r.Form)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) verifyKey(w http.ResponseWriter, form url.Values) {
+	if form.Get("key") != s.APIKey {
+		invalid(w, "Invalid API key")
+		return
+	}
+
+	fmt.Fprint(w, "valid")
+}
+
+func (s *Server) commentCheck(w http.ResponseWriter, form url.Values) {
+	if !s.validRequest(w, form) {
+		return
+	}
+
+	w.Header().Set("X-akismet-guid", fmt.Sprintf("akismettest-%d", len(s.Requests())))
+	switch s.verdict(form) {
+	case Spam:
+		fmt.Fprint(w, "true")
+	case Discard:
+		w.Header().Set("X-akismet-pro-tip", "discard")
+		fmt.Fprint(w, "true")
+	default:
+		fmt.Fprint(w, "false")
+	}
+}
+
+func (s *Server) submit(w http.ResponseWriter, form url.Values) {
+	if !s.validRequest(w, form) {
+		return
+	}
+
+	fmt.Fprint(w, SubmitResponse)
+}
+
+func (s *Server) validRequest(w http.ResponseWriter, form url.Values) bool {
+	switch {
+	case form.Get("api_key") != s.APIKey:
+		invalid(w, "Invalid API key")
+	case form.Get("blog") == "":
+		invalid(w, `Empty "blog" value`)
+	case form.Get("user_ip") == "":
+		invalid(w, `Empty "user_ip" value`)
+	default:
+		return true
+	}
+
+	return false
+}
+
+func (s *Server) verdict(form url.Values) Verdict {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.verdicts) > 0 {
+		v := s.verdicts[0]
+		s.verdicts = s.verdicts[1:]
+		return v
+	}
+
+	switch {
+	case form.Get("user_role") == HamUserRole:
+		return Ham
+	case form.Get("comment_author") == SpamAuthor, form.Get("comment_author_email") == SpamAuthorEmail:
+		return Spam
+	}
+
+	return Ham
+}
+
+func invalid(w http.ResponseWriter, help string) {
+	w.Header().Set("X-akismet-debug-help", help)
+	fmt.Fprint(w, "invalid")
+}
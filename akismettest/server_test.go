@@ -0,0 +1,102 @@
+package akismettest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/SebastianCzoch/akismet-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(s *Server, apiKey string) *akismet.Client {
+	return akismet.NewClient(apiKey, "http://example.com", akismet.WithBaseURL(s.URL))
+}
+
+func TestVerifyKey(t *testing.T) {
+	s := NewServer("test_api_key")
+	defer s.Close()
+
+	assert.Nil(t, newTestClient(s, "test_api_key").VerifyKey(context.Background()))
+
+	err := newTestClient(s, "wrong_key").VerifyKey(context.Background())
+	assert.True(t, errors.Is(err, akismet.ErrInvalidKey))
+}
+
+func TestCommentCheckTriggers(t *testing.T) {
+	s := NewServer("test_api_key")
+	defer s.Close()
+	client := newTestClient(s, "test_api_key")
+
+	options := akismet.Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent", Author: SpamAuthor}
+	isSpam, err := client.IsSpam(options)
+	assert.Nil(t, err)
+	assert.True(t, isSpam)
+
+	options = akismet.Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent", AuthorEmail: SpamAuthorEmail}
+	isSpam, err = client.IsSpam(options)
+	assert.Nil(t, err)
+	assert.True(t, isSpam)
+
+	options.UserRole = HamUserRole
+	isSpam, err = client.IsSpam(options)
+	assert.Nil(t, err)
+	assert.False(t, isSpam)
+
+	options = akismet.Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	isSpam, err = client.IsSpam(options)
+	assert.Nil(t, err)
+	assert.False(t, isSpam)
+}
+
+func TestCommentCheckQueuedVerdicts(t *testing.T) {
+	s := NewServer("test_api_key")
+	defer s.Close()
+	client := newTestClient(s, "test_api_key")
+	s.QueueVerdicts(Discard, Spam)
+
+	options := akismet.Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	res, err := client.Check(options)
+	assert.Nil(t, err)
+	assert.Equal(t, akismet.Discard, res.Verdict)
+	assert.NotEmpty(t, res.GUID)
+
+	res, err = client.Check(options)
+	assert.Nil(t, err)
+	assert.Equal(t, akismet.Spam, res.Verdict)
+
+	res, err = client.Check(options)
+	assert.Nil(t, err)
+	assert.Equal(t, akismet.Ham, res.Verdict)
+}
+
+func TestCommentCheckInvalidKey(t *testing.T) {
+	s := NewServer("test_api_key")
+	defer s.Close()
+
+	options := akismet.Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	_, err := newTestClient(s, "wrong_key").Check(options)
+	assert.True(t, errors.Is(err, akismet.ErrInvalidRequest))
+}
+
+func TestSubmitAndRequests(t *testing.T) {
+	s := NewServer("test_api_key")
+	defer s.Close()
+	client := newTestClient(s, "test_api_key")
+
+	options := akismet.Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent", Content: "Buy now"}
+	assert.Nil(t, client.SubmitSpam(options))
+	assert.Nil(t, client.SubmitHam(options))
+
+	requests := s.Requests()
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, "submit-spam", requests[0].Endpoint)
+		assert.Equal(t, "submit-ham", requests[1].Endpoint)
+		assert.Equal(t, "POST", requests[0].Method)
+		assert.Equal(t, "Buy now", requests[0].Form.Get("comment_content"))
+		assert.Equal(t, "http://example.com", requests[0].Form.Get("blog"))
+	}
+
+	s.Reset()
+	assert.Empty(t, s.Requests())
+}
@@ -0,0 +1,97 @@
+package akismet
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchConfig describes how CheckBatch checks content
+type BatchConfig struct {
+	// Workers is a number of concurrent requests, default is 4
+	Workers int
+	// RateLimit caps requests sent by the batch, zero value means no limit
+	RateLimit RateLimit
+}
+
+// BatchResult is a result of checking single item of a batch, Index is position of the item in input slice
+type BatchResult struct {
+	Index  int
+	Result *CheckResult
+	Err    error
+}
+
+// CheckBatch checks all passed Options concurrently. Results are sent in order of completion,
+// one for every item, and the channel is closed when all items are checked. Error of one item
+// does not stop the batch. Cancel ctx to stop it, no more items are checked and no more results
+// are sent after that, so the channel can be abandoned.
+func (c *Client) CheckBatch(ctx context.Context, options []Options, cfg BatchConfig) <-chan BatchResult {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = 4
+	}
+
+	limiter := newRateLimiter([]RateLimit{cfg.RateLimit}, false)
+	jobs := make(chan int)
+	results := make(chan BatchResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				result := BatchResult{Index: index}
+				if result.Err = limiter.wait(ctx); result.Err == nil {
+					result.Result, result.Err = c.CheckContext(ctx, options[index])
+				}
+
+				select {
+				case results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer func() {
+			close(jobs)
+			wg.Wait()
+			close(results)
+		}()
+
+		for index := range options {
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case jobs <- index:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results
+}
+
+// CheckAll is like CheckBatch but waits for all results and returns them in input order,
+// items not checked because ctx was cancelled get ctx error
+func (c *Client) CheckAll(ctx context.Context, options []Options, cfg BatchConfig) []BatchResult {
+	results := make([]BatchResult, len(options))
+	done := make([]bool, len(options))
+	for r := range c.CheckBatch(ctx, options, cfg) {
+		results[r.Index] = r
+		done[r.Index] = true
+	}
+
+	for i := range results {
+		if !done[i] {
+			results[i] = BatchResult{Index: i, Err: ctx.Err()}
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,127 @@
+package akismet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAll(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		r.ParseForm()
+		w.Write([]byte(map[string]string{"spam": "true", "ham": "false", "bad": "invalid"}[r.PostForm.Get("comment_content")]))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL))
+	var options []Options
+	for i := 0; i < 12; i++ {
+		content := []string{"spam", "ham", "bad"}[i%3]
+		options = append(options, Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent", Content: content})
+	}
+	options = append(options, Options{})
+
+	results := client.CheckAll(context.Background(), options, BatchConfig{Workers: 3})
+	assert.Len(t, results, 13)
+	for i, r := range results[:12] {
+		assert.Equal(t, i, r.Index)
+		switch i % 3 {
+		case 0:
+			assert.Nil(t, r.Err)
+			assert.Equal(t, Spam, r.Result.Verdict)
+		case 1:
+			assert.Nil(t, r.Err)
+			assert.Equal(t, Ham, r.Result.Verdict)
+		case 2:
+			assert.True(t, errors.Is(r.Err, ErrInvalidRequest))
+		}
+	}
+	assert.True(t, errors.Is(results[12].Err, ErrMissingUserIP))
+	assert.True(t, atomic.LoadInt32(&maxInFlight) <= 3)
+}
+
+func TestCheckBatchRateLimit(t *testing.T) {
+	server, calls := newCountingServer("false")
+	defer server.Close()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL))
+	options := make([]Options, 4)
+	for i := range options {
+		options[i] = Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	}
+
+	start := time.Now()
+	count := 0
+	for r := range client.CheckBatch(context.Background(), options, BatchConfig{RateLimit: RateLimit{Limit: 1, Per: 20 * time.Millisecond}}) {
+		assert.Nil(t, r.Err)
+		count++
+	}
+	assert.Equal(t, 4, count)
+	assert.True(t, time.Since(start) >= 55*time.Millisecond)
+	assert.Equal(t, int32(4), atomic.LoadInt32(calls))
+}
+
+func TestCheckBatchCanceled(t *testing.T) {
+	server, _ := newCountingServer("false")
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL))
+	options := []Options{{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}, {UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}}
+	for _, r := range client.CheckAll(ctx, options, BatchConfig{}) {
+		assert.True(t, errors.Is(r.Err, context.Canceled))
+	}
+}
+
+func TestCheckBatchCancel(t *testing.T) {
+	server, calls := newCountingServer("false")
+	defer server.Close()
+
+	// connections are not kept alive, so only batch goroutines are counted
+	httpClient := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithHTTPClient(httpClient))
+	options := make([]Options, 1000)
+	for i := range options {
+		options[i] = Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	}
+
+	goroutines := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	results := client.CheckBatch(ctx, options, BatchConfig{Workers: 10})
+	<-results
+	cancel()
+
+	// channel is abandoned, all goroutines of the batch must exit anyway
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.True(t, runtime.NumGoroutine() <= goroutines, "goroutines leaked")
+	assert.True(t, atomic.LoadInt32(calls) < 100)
+
+	all := client.CheckAll(ctx, options[:3], BatchConfig{})
+	for i, r := range all {
+		assert.Equal(t, i, r.Index)
+		assert.True(t, errors.Is(r.Err, context.Canceled))
+	}
+}
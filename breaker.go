@@ -0,0 +1,117 @@
+package akismet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// FallbackPolicy decides verdict returned by comment-check while circuit breaker is open
+type FallbackPolicy int
+
+// Possible fallback policies
+const (
+	// FailOpen accepts content as Ham
+	FailOpen FallbackPolicy = iota
+	// FailClosed treats content as Spam
+	FailClosed
+	// HoldForModeration returns Hold verdict
+	HoldForModeration
+)
+
+// CircuitBreakerConfig describes circuit breaker. After Threshold consecutive failures
+// requests are not sent for Cooldown, then single request is let through to check if
+// Akismet is back. Failures are transport errors, client timeouts, HTTP 429 and 5xx.
+// Cancelled or expired context of the caller is not a failure.
+type CircuitBreakerConfig struct {
+	Threshold int
+	Cooldown  time.Duration
+	Fallback  FallbackPolicy
+}
+
+// WithCircuitBreaker sets circuit breaker
+func WithCircuitBreaker(cfg CircuitBreakerConfig) Option {
+	return func(c *Client) {
+		c.breaker = nil
+		if cfg.Threshold > 0 {
+			c.breaker = &circuitBreaker{cfg: cfg}
+		}
+	}
+}
+
+type circuitBreaker struct {
+	mu       sync.Mutex
+	cfg      CircuitBreakerConfig
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func (b *circuitBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.cfg.Threshold {
+		return true
+	}
+
+	if !b.probing && time.Since(b.openedAt) >= b.cfg.Cooldown {
+		b.probing = true
+		return true
+	}
+
+	return false
+}
+
+// record counts result of request sent with ctx, failures caused by cancelled ctx or
+// caller's deadline are not Akismet failures and are ignored
+func (b *circuitBreaker) record(ctx context.Context, err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	switch {
+	case ctx.Err() != nil:
+	case isServiceFailure(err):
+		b.failures++
+		if b.failures >= b.cfg.Threshold {
+			b.openedAt = time.Now()
+		}
+	case err == nil:
+		b.failures = 0
+	}
+}
+
+func (b *circuitBreaker) fallback() *CheckResult {
+	result := &CheckResult{Verdict: Ham, Fallback: true}
+	switch b.cfg.Fallback {
+	case FailClosed:
+		result.Verdict = Spam
+	case HoldForModeration:
+		result.Verdict = Hold
+	}
+
+	return result
+}
+
+// isServiceFailure reports whether err means Akismet is unavailable
+func isServiceFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, ErrRateLimited) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+
+	return true
+}
@@ -0,0 +1,120 @@
+package akismet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakerFallback(t *testing.T) {
+	var down int32 = 1
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(&down) == 1 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Write([]byte("false"))
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		policy  FallbackPolicy
+		verdict Verdict
+	}{
+		{FailOpen, Ham},
+		{FailClosed, Spam},
+		{HoldForModeration, Hold},
+	} {
+		atomic.StoreInt32(&down, 1)
+		atomic.StoreInt32(&calls, 0)
+		client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithCircuitBreaker(CircuitBreakerConfig{
+			Threshold: 2,
+			Cooldown:  20 * time.Millisecond,
+			Fallback:  tc.policy,
+		}))
+		options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+
+		for i := 0; i < 2; i++ {
+			_, err := client.Check(options)
+			assert.True(t, errors.Is(err, ErrUnexpectedStatus))
+		}
+
+		res, err := client.Check(options)
+		assert.Nil(t, err)
+		assert.Equal(t, &CheckResult{Verdict: tc.verdict, Fallback: true}, res)
+		assert.True(t, errors.Is(client.SubmitSpam(options), ErrCircuitOpen))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+		atomic.StoreInt32(&down, 0)
+		time.Sleep(30 * time.Millisecond)
+		res, err = client.Check(options)
+		assert.Nil(t, err)
+		assert.Equal(t, Ham, res.Verdict)
+		assert.False(t, res.Fallback)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	}
+}
+
+func TestCircuitBreakerIgnoresInvalidRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	}))
+	defer server.Close()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithCircuitBreaker(CircuitBreakerConfig{Threshold: 1, Cooldown: time.Hour}))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	for i := 0; i < 3; i++ {
+		_, err := client.Check(options)
+		assert.True(t, errors.Is(err, ErrUnexpectedStatus))
+	}
+}
+
+func TestCircuitBreakerProbeFails(t *testing.T) {
+	b := &circuitBreaker{cfg: CircuitBreakerConfig{Threshold: 1, Cooldown: 10 * time.Millisecond}}
+	assert.True(t, b.allow())
+	b.record(context.Background(), errors.New("connection refused"))
+	assert.False(t, b.allow())
+
+	time.Sleep(15 * time.Millisecond)
+	assert.True(t, b.allow())
+	assert.False(t, b.allow())
+	b.record(context.Background(), errors.New("connection refused"))
+	assert.False(t, b.allow())
+}
+
+func TestCircuitBreakerIgnoresCallerDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(30 * time.Millisecond)
+		w.Write([]byte("false"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithCircuitBreaker(CircuitBreakerConfig{Threshold: 1, Cooldown: time.Hour}))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		_, err := client.CheckContext(ctx, options)
+		cancel()
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	}
+
+	res, err := client.Check(options)
+	assert.Nil(t, err)
+	assert.False(t, res.Fallback)
+
+	client = NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithTimeout(5*time.Millisecond),
+		WithCircuitBreaker(CircuitBreakerConfig{Threshold: 1, Cooldown: time.Hour}))
+	_, err = client.Check(options)
+	assert.NotNil(t, err)
+	res, err = client.Check(options)
+	assert.Nil(t, err)
+	assert.True(t, res.Fallback)
+}
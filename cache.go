@@ -0,0 +1,176 @@
+package akismet
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores comment-check results by content fingerprint
+type Cache interface {
+	Get(key string) (*CheckResult, bool)
+	Set(key string, result *CheckResult)
+	Delete(key string)
+}
+
+// FingerprintFunc returns cache key for passed Options
+type FingerprintFunc func(o Options) string
+
+// FingerprintField is Options field used to build fingerprint
+type FingerprintField int
+
+// Fields which can be used in fingerprint
+const (
+	FieldContent FingerprintField = iota
+	FieldAuthor
+	FieldAuthorEmail
+	FieldAuthorURL
+	FieldUserIP
+	FieldCommentType
+	FieldPermalink
+)
+
+// DefaultFingerprint uses content and author email
+var DefaultFingerprint = NewFingerprint(FieldContent, FieldAuthorEmail)
+
+// NewFingerprint returns FingerprintFunc which hashes passed fields, values are
+// normalized (case and whitespace are ignored), so the same re-posted content gets the same key
+func NewFingerprint(fields ...FingerprintField) FingerprintFunc {
+	return func(o Options) string {
+		h := sha256.New()
+		for _, field := range fields {
+			h.Write([]byte(normalize(o.field(field))))
+			h.Write([]byte{0})
+		}
+
+		return hex.EncodeToString(h.Sum(nil))
+	}
+}
+
+func (o *Options) field(f FingerprintField) string {
+	switch f {
+	case FieldContent:
+		return o.Content
+	case FieldAuthor:
+		return o.Author
+	case FieldAuthorEmail:
+		return o.AuthorEmail
+	case FieldAuthorURL:
+		return o.AuthorURL
+	case FieldUserIP:
+		return o.UserIP
+	case FieldCommentType:
+		return string(o.CommentType)
+	case FieldPermalink:
+		return o.Permalink
+	}
+
+	return ""
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// WithCache puts cache in front of comment-check, fingerprint can be nil to use DefaultFingerprint.
+// Successful SubmitSpam and SubmitHam remove matching entry.
+func WithCache(cache Cache, fingerprint FingerprintFunc) Option {
+	return func(c *Client) {
+		if fingerprint == nil {
+			fingerprint = DefaultFingerprint
+		}
+
+		c.cache = cache
+		c.fingerprint = fingerprint
+	}
+}
+
+// LRUCache is in-memory Cache which keeps at most size entries, each for ttl
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type lruEntry struct {
+	key     string
+	result  CheckResult
+	expires time.Time
+}
+
+// NewLRUCache creates LRUCache, zero ttl means entries do not expire
+func NewLRUCache(size int, ttl time.Duration) *LRUCache {
+	return &LRUCache{
+		size:    size,
+		ttl:     ttl,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// Get returns copy of cached result
+func (c *LRUCache) Get(key string) (*CheckResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := e.Value.(*lruEntry)
+	if c.ttl > 0 && time.Now().After(entry.expires) {
+		c.remove(e)
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+	result := entry.result
+	return &result, true
+}
+
+// Set stores copy of result, evicting least recently used entry when cache is full
+func (c *LRUCache) Set(key string, result *CheckResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &lruEntry{key: key, result: *result, expires: time.Now().Add(c.ttl)}
+	if e, ok := c.entries[key]; ok {
+		e.Value = entry
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	for c.size > 0 && c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Delete removes entry
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+}
+
+// Len returns number of entries
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *LRUCache) remove(e *list.Element) {
+	c.order.Remove(e)
+	delete(c.entries, e.Value.(*lruEntry).key)
+}
@@ -0,0 +1,92 @@
+package akismet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCache(t *testing.T) {
+	cache := NewLRUCache(2, 0)
+	cache.Set("a", &CheckResult{Verdict: Spam})
+	cache.Set("b", &CheckResult{Verdict: Ham})
+
+	r, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, Spam, r.Verdict)
+	r.Verdict = Ham
+
+	cache.Set("c", &CheckResult{Verdict: Discard})
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	r, ok = cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, Spam, r.Verdict)
+	assert.Equal(t, 2, cache.Len())
+
+	cache.Delete("a")
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestLRUCacheTTL(t *testing.T) {
+	cache := NewLRUCache(10, 10*time.Millisecond)
+	cache.Set("a", &CheckResult{Verdict: Spam})
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+
+	time.Sleep(15 * time.Millisecond)
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestFingerprint(t *testing.T) {
+	a := Options{Content: "Buy   CHEAP\nwatches", AuthorEmail: "Bot@example.com", UserIP: "192.0.2.1"}
+	b := Options{Content: " buy cheap\nwatches ", AuthorEmail: "bot@example.com", UserIP: "192.0.2.2"}
+	assert.Equal(t, DefaultFingerprint(a), DefaultFingerprint(b))
+
+	withIP := NewFingerprint(FieldContent, FieldUserIP)
+	assert.NotEqual(t, withIP(a), withIP(b))
+
+	assert.NotEqual(t, NewFingerprint(FieldContent, FieldAuthor)(Options{Content: "ab"}), NewFingerprint(FieldContent, FieldAuthor)(Options{Content: "a", Author: "b"}))
+}
+
+func TestCheckCache(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.URL.Path == "/1.1/comment-check" {
+			w.Write([]byte("true"))
+			return
+		}
+		w.Write([]byte(SubmitResponseContentOK))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithCache(NewLRUCache(100, time.Hour), nil))
+	first := Options{UserIP: "192.0.2.1", UserAgent: "TestUserAgent", Content: "Buy cheap watches"}
+	second := Options{UserIP: "192.0.2.2", UserAgent: "TestUserAgent", Content: "buy cheap  watches"}
+
+	res, err := client.Check(first)
+	assert.Nil(t, err)
+	assert.Equal(t, Spam, res.Verdict)
+	assert.False(t, res.CacheHit)
+
+	res, err = client.Check(second)
+	assert.Nil(t, err)
+	assert.Equal(t, Spam, res.Verdict)
+	assert.True(t, res.CacheHit)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	assert.Nil(t, client.SubmitHam(second))
+	res, err = client.Check(first)
+	assert.Nil(t, err)
+	assert.False(t, res.CacheHit)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
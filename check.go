@@ -0,0 +1,146 @@
+package akismet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Verdict is a result of comment-check call
+type Verdict int
+
+// Possible verdicts, Discard is blatant spam which can be dropped without review.
+// Hold is returned only by circuit breaker with HoldForModeration policy.
+const (
+	Ham Verdict = iota
+	Spam
+	Discard
+	Hold
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case Spam:
+		return "spam"
+	case Discard:
+		return "discard"
+	case Hold:
+		return "hold"
+	}
+
+	return "ham"
+}
+
+// MarshalText encodes verdict as its name, e.g. "spam"
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText decodes verdict from its name
+func (v *Verdict) UnmarshalText(text []byte) error {
+	for _, verdict := range []Verdict{Ham, Spam, Discard, Hold} {
+		if verdict.String() == string(text) {
+			*v = verdict
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown verdict %q", text)
+}
+
+// CheckResult is a struct which contains verdict and metadata sent by Akismet with comment-check response
+type CheckResult struct {
+	Verdict   Verdict `json:"verdict"`
+	ProTip    string  `json:"pro_tip,omitempty"`
+	GUID      string  `json:"guid,omitempty"`
+	DebugHelp string  `json:"debug_help,omitempty"`
+	AlertCode string  `json:"alert_code,omitempty"`
+	AlertMsg  string  `json:"alert_msg,omitempty"`
+
+	// Fallback is true when verdict comes from circuit breaker policy, not from Akismet
+	Fallback bool `json:"fallback,omitempty"`
+	// CacheHit is true when result comes from cache
+	CacheHit bool `json:"cache_hit,omitempty"`
+}
+
+// IsSpam reports whether verdict is other than Ham, content held for moderation is not accepted
+func (r *CheckResult) IsSpam() bool {
+	return r.Verdict != Ham
+}
+
+// Check is a method which check passed Options struct and returns verdict with response metadata
+func (c *Client) Check(o Options) (*CheckResult, error) {
+	return c.CheckContext(context.Background(), o)
+}
+
+// CheckContext is like Check but the request is bound to ctx
+func (c *Client) CheckContext(ctx context.Context, o Options) (*CheckResult, error) {
+	ctx, span := c.startSpan(ctx, "comment-check")
+	result, err := c.check(ctx, o)
+	span.recordResult(result)
+	span.end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logVerdict(ctx, result)
+	if c.metrics != nil {
+		c.metrics.ObserveVerdict(result)
+	}
+
+	return result, nil
+}
+
+func (c *Client) check(ctx context.Context, o Options) (*CheckResult, error) {
+	var key string
+	if c.cache != nil {
+		key = c.fingerprint(o)
+		if result, ok := c.cache.Get(key); ok {
+			result.CacheHit = true
+			return result, nil
+		}
+	}
+
+	r, err := c.makeRequest(ctx, o, EndpointCommentCheck)
+	if errors.Is(err, ErrCircuitOpen) {
+		return c.breaker.fallback(), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CheckResult{
+		Verdict:   Ham,
+		ProTip:    r.header.Get("X-akismet-pro-tip"),
+		GUID:      r.header.Get("X-akismet-guid"),
+		DebugHelp: r.header.Get("X-akismet-debug-help"),
+		AlertCode: r.header.Get("X-akismet-alert-code"),
+		AlertMsg:  r.header.Get("X-akismet-alert-msg"),
+	}
+
+	switch r.body {
+	case "false":
+	case "true":
+		result.Verdict = Spam
+		if result.ProTip == "discard" {
+			result.Verdict = Discard
+		}
+	case "invalid":
+		return nil, r.error(ErrInvalidRequest)
+	default:
+		return nil, r.error(ErrUnexpectedResponse)
+	}
+
+	if c.cache != nil {
+		c.cache.Set(key, result)
+	}
+
+	return result, nil
+}
+
+func (c *Client) invalidate(o Options) {
+	if c.cache != nil {
+		c.cache.Delete(c.fingerprint(o))
+	}
+}
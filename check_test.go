@@ -0,0 +1,139 @@
+package akismet
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func checkResponder(body string, header map[string]string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		res := httpmock.NewStringResponse(200, body)
+		for k, v := range header {
+			res.Header.Set(k, v)
+		}
+		return res, nil
+	}
+}
+
+func TestCheckDiscard(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/comment-check", checkResponder("true", map[string]string{
+		"X-akismet-pro-tip":    "discard",
+		"X-akismet-guid":       "abc123",
+		"X-akismet-alert-code": "10003",
+		"X-akismet-alert-msg":  "Usage limit",
+	}))
+
+	client := NewClient("test_api_key", "test_site")
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	res, err := client.Check(options)
+	assert.Nil(t, err)
+	assert.Equal(t, &CheckResult{
+		Verdict:   Discard,
+		ProTip:    "discard",
+		GUID:      "abc123",
+		AlertCode: "10003",
+		AlertMsg:  "Usage limit",
+	}, res)
+	assert.True(t, res.IsSpam())
+
+	isSpam, err := client.IsSpam(options)
+	assert.Nil(t, err)
+	assert.True(t, isSpam)
+}
+
+func TestCheckSpam(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/comment-check", checkResponder("true", map[string]string{"X-akismet-guid": "abc123"}))
+
+	client := NewClient("test_api_key", "test_site")
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	res, err := client.Check(options)
+	assert.Nil(t, err)
+	assert.Equal(t, Spam, res.Verdict)
+	assert.Equal(t, "abc123", res.GUID)
+}
+
+func TestCheckHam(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/comment-check", checkResponder("false", nil))
+
+	client := NewClient("test_api_key", "test_site")
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	res, err := client.Check(options)
+	assert.Nil(t, err)
+	assert.Equal(t, Ham, res.Verdict)
+	assert.False(t, res.IsSpam())
+}
+
+func TestCheckInvalid(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/comment-check", checkResponder("invalid", map[string]string{"X-akismet-debug-help": "Empty \"blog\" value"}))
+
+	client := NewClient("test_api_key", "test_site")
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	res, err := client.Check(options)
+	assert.Nil(t, res)
+	assert.Error(t, err)
+}
+
+func TestCheckUnexpectedResponse(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := NewClient("test_api_key", "test_site")
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	for _, body := range []string{"", "False", "<html>Maintenance</html>"} {
+		httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/comment-check", checkResponder(body, nil))
+		res, err := client.Check(options)
+		assert.Nil(t, res)
+		assert.True(t, errors.Is(err, ErrUnexpectedResponse), body)
+
+		var apiErr *APIError
+		if assert.True(t, errors.As(err, &apiErr)) {
+			assert.Equal(t, body, apiErr.Body)
+		}
+	}
+}
+
+func TestVerdictString(t *testing.T) {
+	assert.Equal(t, "ham", Ham.String())
+	assert.Equal(t, "spam", Spam.String())
+	assert.Equal(t, "discard", Discard.String())
+}
+
+func TestVerdictText(t *testing.T) {
+	for _, v := range []Verdict{Ham, Spam, Discard, Hold} {
+		text, err := v.MarshalText()
+		assert.Nil(t, err)
+		assert.Equal(t, v.String(), string(text))
+
+		var decoded Verdict
+		assert.Nil(t, decoded.UnmarshalText(text))
+		assert.Equal(t, v, decoded)
+	}
+
+	var v Verdict
+	assert.Error(t, v.UnmarshalText([]byte("maybe")))
+}
+
+func TestCheckResultJSON(t *testing.T) {
+	data, err := json.Marshal(&CheckResult{Verdict: Discard, ProTip: "discard", GUID: "abc123", CacheHit: true})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"verdict":"discard","pro_tip":"discard","guid":"abc123","cache_hit":true}`, string(data))
+
+	var result CheckResult
+	assert.Nil(t, json.Unmarshal(data, &result))
+	assert.Equal(t, CheckResult{Verdict: Discard, ProTip: "discard", GUID: "abc123", CacheHit: true}, result)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"verdict":"maybe"}`), &result))
+}
@@ -0,0 +1,128 @@
+// Command akismet-proxy is HTTP sidecar which exposes Akismet as internal JSON API:
+//
+//	POST /check        Options JSON, responds with CheckResult JSON
+//	POST /submit/spam  Options JSON
+//	POST /submit/ham   Options JSON
+//	GET  /healthz
+//	GET  /debug/vars   expvar metrics
+//
+// With -grpc-listen it also serves akismetpb.Akismet gRPC service.
+//
+// API key and site are read from AKISMET_API_KEY and AKISMET_SITE environment variables,
+// AKISMET_BASE_URL overrides API address.
+package main
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/SebastianCzoch/akismet-go"
+	"github.com/SebastianCzoch/akismet-go/akismetpb"
+	"github.com/SebastianCzoch/akismet-go/grpcakismet"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	listen := flag.String("listen", ":8080", "listen address")
+	grpcListen := flag.String("grpc-listen", "", "gRPC listen address (default gRPC is disabled)")
+	timeout := flag.Duration("timeout", 10*time.Second, "Akismet request timeout")
+	cacheSize := flag.Int("cache-size", 10000, "number of cached comment-check results, 0 disables cache")
+	cacheTTL := flag.Duration("cache-ttl", time.Hour, "comment-check cache TTL")
+	checkRate := flag.Int("check-rate", 0, "max comment-check requests per second (default no limit)")
+	submitRate := flag.Int("submit-rate", 0, "max submit requests per second (default no limit)")
+	failFast := flag.Bool("fail-fast", false, "respond with 429 instead of waiting when rate limit is exceeded")
+	verify := flag.Bool("verify", true, "verify API key on start")
+	flag.Parse()
+
+	apiKey, site := os.Getenv("AKISMET_API_KEY"), os.Getenv("AKISMET_SITE")
+	if apiKey == "" || site == "" {
+		log.Fatal("AKISMET_API_KEY and AKISMET_SITE are required")
+	}
+
+	metrics := akismet.NewExpvarMetrics("akismet")
+	opts := []akismet.Option{
+		akismet.WithUserAgent("akismet-proxy/" + akismet.LibraryVersion),
+		akismet.WithTimeout(*timeout),
+		akismet.WithMetrics(metrics),
+		akismet.WithRetry(akismet.DefaultRetryPolicy()),
+		akismet.WithRateLimit(akismet.RateLimitConfig{
+			Check:    perSecond(*checkRate),
+			Submit:   perSecond(*submitRate),
+			FailFast: *failFast,
+		}),
+	}
+	if baseURL := os.Getenv("AKISMET_BASE_URL"); baseURL != "" {
+		opts = append(opts, akismet.WithBaseURL(baseURL))
+	}
+	if *cacheSize > 0 {
+		opts = append(opts, akismet.WithCache(akismet.NewLRUCache(*cacheSize, *cacheTTL), nil))
+	}
+	client := akismet.NewClient(apiKey, site, opts...)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *verify {
+		if err := client.VerifyKey(ctx); err != nil {
+			log.Fatalf("cannot verify API key: %s", err)
+		}
+	}
+
+	srv := &http.Server{
+		Addr:              *listen,
+		Handler:           newHandler(client, expvar.Handler()),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	if *grpcListen != "" {
+		listener, err := net.Listen("tcp", *grpcListen)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		grpcServer := grpc.NewServer()
+		akismetpb.RegisterAkismetServer(grpcServer, grpcakismet.NewServer(client, 0))
+		go func() {
+			<-ctx.Done()
+			grpcServer.GracefulStop()
+		}()
+		go func() {
+			log.Printf("gRPC listening on %s", *grpcListen)
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
+
+	log.Printf("listening on %s", *listen)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+	<-done
+}
+
+func perSecond(limit int) []akismet.RateLimit {
+	if limit <= 0 {
+		return nil
+	}
+
+	return []akismet.RateLimit{{Limit: limit, Per: time.Second, Burst: limit}}
+}
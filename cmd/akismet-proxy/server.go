@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/SebastianCzoch/akismet-go"
+)
+
+// maxBodySize limits size of request body
+const maxBodySize = 1 << 20
+
+type server struct {
+	client *akismet.Client
+}
+
+// newHandler returns handler which serves JSON API, metrics can be nil
+func newHandler(client *akismet.Client, metrics http.Handler) http.Handler {
+	s := &server{client: client}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/check", s.check)
+	mux.HandleFunc("/submit/spam", s.submit(client.SubmitSpamContext))
+	mux.HandleFunc("/submit/ham", s.submit(client.SubmitHamContext))
+	mux.HandleFunc("/healthz", s.healthz)
+	if metrics != nil {
+		mux.Handle("/debug/vars", metrics)
+	}
+
+	return mux
+}
+
+func (s *server) check(w http.ResponseWriter, r *http.Request) {
+	o, ok := readOptions(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := s.client.CheckContext(r.Context(), o)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *server) submit(submit func(ctx context.Context, o akismet.Options) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		o, ok := readOptions(w, r)
+		if !ok {
+			return
+		}
+
+		if err := submit(r.Context(), o); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]bool{"submitted": true})
+	}
+}
+
+func (s *server) healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func readOptions(w http.ResponseWriter, r *http.Request) (akismet.Options, bool) {
+	var o akismet.Options
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+		return o, false
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err := decoder.Decode(&o); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid JSON: " + err.Error()})
+		return o, false
+	}
+
+	return o, true
+}
+
+type errorResponse struct {
+	Error     string `json:"error"`
+	DebugHelp string `json:"debug_help,omitempty"`
+}
+
+// writeError maps client errors to HTTP status codes
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	switch {
+	case errors.Is(err, akismet.ErrMissingUserIP), errors.Is(err, akismet.ErrMissingUserAgent), errors.Is(err, akismet.ErrInvalidRequest),
+		errors.Is(err, akismet.ErrInvalidParameter):
+		status = http.StatusBadRequest
+	case errors.Is(err, akismet.ErrRateLimited):
+		status = http.StatusTooManyRequests
+	case errors.Is(err, akismet.ErrCircuitOpen):
+		status = http.StatusServiceUnavailable
+	}
+
+	response := errorResponse{Error: err.Error()}
+	var apiErr *akismet.APIError
+	if errors.As(err, &apiErr) {
+		response.DebugHelp = apiErr.DebugHelp
+	}
+
+	writeJSON(w, status, response)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
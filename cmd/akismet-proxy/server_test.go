@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SebastianCzoch/akismet-go"
+	"github.com/SebastianCzoch/akismet-go/akismettest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestProxy(s *akismettest.Server, opts ...akismet.Option) http.Handler {
+	opts = append(opts, akismet.WithBaseURL(s.URL))
+	return newHandler(akismet.NewClient("test_api_key", "http://example.com", opts...), nil)
+}
+
+func post(h http.Handler, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	return w
+}
+
+func TestProxyCheck(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+	h := newTestProxy(s)
+
+	w := post(h, "/check", `{"user_ip": "127.0.0.1", "user_agent": "curl", "comment_author": "`+akismettest.SpamAuthor+`"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var result akismet.CheckResult
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, akismet.Spam, result.Verdict)
+	assert.Equal(t, "akismettest-1", result.GUID)
+
+	form := s.Requests()[0].Form
+	assert.Equal(t, "127.0.0.1", form.Get("user_ip"))
+	assert.Equal(t, "http://example.com", form.Get("blog"))
+}
+
+func TestProxySubmit(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+	h := newTestProxy(s)
+
+	w := post(h, "/submit/spam", `{"user_ip": "127.0.0.1", "user_agent": "curl"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "{\"submitted\":true}\n", w.Body.String())
+
+	w = post(h, "/submit/ham", `{"user_ip": "127.0.0.1", "user_agent": "curl"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	requests := s.Requests()
+	assert.Equal(t, "submit-spam", requests[0].Endpoint)
+	assert.Equal(t, "submit-ham", requests[1].Endpoint)
+}
+
+func TestProxyErrors(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+	h := newTestProxy(s)
+
+	w := post(h, "/check", `{`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.True(t, strings.HasPrefix(w.Body.String(), `{"error":"invalid JSON`))
+
+	w = post(h, "/check", `{"user_agent": "curl"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = post(h, "/check", `{"user_ip": "127.0.0.1", "user_agent": "curl", "server_env": {"user_ip": "9.9.9.9"}}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = post(h, "/check", `{"user_ip": "127.0.0.1", "user_agent": "curl", "comment_date_gmt": "yesterday"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid parameter")
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/check", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, http.MethodPost, w.Header().Get("Allow"))
+
+	assert.Len(t, s.Requests(), 0)
+}
+
+func TestProxyUpstreamFailure(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	h := newTestProxy(s)
+	s.Close()
+
+	w := post(h, "/check", `{"user_ip": "127.0.0.1", "user_agent": "curl"}`)
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+}
+
+func TestProxyRateLimited(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+	h := newTestProxy(s, akismet.WithRateLimit(akismet.RateLimitConfig{
+		Check:    []akismet.RateLimit{{Limit: 1, Per: time.Hour, Burst: 1}},
+		FailFast: true,
+	}))
+
+	body := `{"user_ip": "127.0.0.1", "user_agent": "curl"}`
+	assert.Equal(t, http.StatusOK, post(h, "/check", body).Code)
+	assert.Equal(t, http.StatusTooManyRequests, post(h, "/check", body).Code)
+}
+
+func TestProxyHealthzAndMetrics(t *testing.T) {
+	metrics := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("metrics")) })
+	h := newHandler(akismet.NewClient("test_api_key", "http://example.com"), metrics)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "{\"status\":\"ok\"}\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+	assert.Equal(t, "metrics", w.Body.String())
+}
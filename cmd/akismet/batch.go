@@ -0,0 +1,497 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SebastianCzoch/akismet-go"
+)
+
+// Columns added to every output record
+const (
+	columnVerdict = "akismet_verdict"
+	columnGUID    = "akismet_guid"
+	columnError   = "akismet_error"
+)
+
+// record is a single input record, keys are column names
+type record map[string]interface{}
+
+type recordReader interface {
+	// Read returns next record or io.EOF
+	Read() (record, error)
+}
+
+type recordWriter interface {
+	Write(r record, result *akismet.CheckResult, err error) error
+	Flush() error
+}
+
+// mappingFlag collects repeated -map column=field flags
+type mappingFlag map[string]string
+
+func (m mappingFlag) String() string {
+	pairs := make([]string, 0, len(m))
+	for column, field := range m {
+		pairs = append(pairs, column+"="+field)
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+func (m mappingFlag) Set(value string) error {
+	for _, pair := range strings.Split(value, ",") {
+		column, field, ok := strings.Cut(pair, "=")
+		if !ok || column == "" || field == "" {
+			return fmt.Errorf("mapping must be column=field, got %q", pair)
+		}
+		m[column] = field
+	}
+
+	return nil
+}
+
+type batchFlags struct {
+	format      string
+	output      string
+	checkpoint  string
+	commentType string
+	mapping     mappingFlag
+	workers     int
+	rate        int
+	chunk       int
+	quiet       bool
+}
+
+// checkpoint is saved after every chunk of records written to output
+type checkpoint struct {
+	Processed int `json:"processed"`
+}
+
+func (e *env) batch(args []string) int {
+	fs, c := e.newFlagSet("batch")
+	f := &batchFlags{mapping: mappingFlag{}}
+	fs.StringVar(&f.format, "format", "", "input and output format, jsonl or csv (default from input file extension)")
+	fs.StringVar(&f.output, "o", "", "output file (default stdout)")
+	fs.StringVar(&f.checkpoint, "checkpoint", "", "checkpoint file, batch is resumed from it when it exists")
+	fs.StringVar(&f.commentType, "type", "", "comment type of records without comment_type")
+	fs.Var(f.mapping, "map", "map input column to Options field, e.g. -map body=comment_content (repeatable)")
+	fs.IntVar(&f.workers, "workers", 4, "number of concurrent requests")
+	fs.IntVar(&f.rate, "rate", 0, "max requests per second (default no limit)")
+	fs.IntVar(&f.chunk, "chunk", 1000, "number of records checked between checkpoints")
+	fs.BoolVar(&f.quiet, "quiet", false, "do not report progress")
+	fs.Usage = func() {
+		fmt.Fprintln(e.stderr, "Usage: akismet batch [flags] [input file]")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	input := ""
+	if fs.NArg() > 0 {
+		input = fs.Arg(0)
+	}
+
+	if f.format == "" {
+		f.format = strings.TrimPrefix(filepath.Ext(input), ".")
+	}
+
+	if f.format != "jsonl" && f.format != "csv" {
+		fmt.Fprintln(e.stderr, "akismet: -format must be jsonl or csv")
+		return exitUsage
+	}
+
+	if f.chunk <= 0 {
+		f.chunk = 1000
+	}
+
+	client, err := e.client(c)
+	if err != nil {
+		return e.fail(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := e.runBatch(ctx, client, input, f); err != nil {
+		return e.fail(err)
+	}
+
+	return exitOK
+}
+
+func (e *env) runBatch(ctx context.Context, client *akismet.Client, input string, f *batchFlags) error {
+	cp, err := loadCheckpoint(f.checkpoint)
+	if err != nil {
+		return err
+	}
+
+	in := e.stdin
+	if input != "" && input != "-" {
+		file, err := os.Open(input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	out := e.stdout
+	resumed := cp.Processed > 0
+	if f.output != "" {
+		flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if resumed {
+			flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+		}
+
+		file, err := os.OpenFile(f.output, flags, 0644)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	var reader recordReader
+	var writer recordWriter
+	if f.format == "csv" {
+		r, err := newCSVReader(in)
+		if err != nil {
+			return err
+		}
+		reader, writer = r, newCSVWriter(out, r.header, !resumed)
+	} else {
+		reader, writer = newJSONLReader(in), newJSONLWriter(out)
+	}
+
+	for i := 0; i < cp.Processed; i++ {
+		if _, err := reader.Read(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	cfg := akismet.BatchConfig{Workers: f.workers}
+	if f.rate > 0 {
+		cfg.RateLimit = akismet.RateLimit{Limit: f.rate, Per: time.Second, Burst: f.workers}
+	}
+
+	p := &progress{start: time.Now(), processed: cp.Processed}
+	for {
+		records, err := readChunk(reader, f.chunk)
+		if err != nil {
+			return err
+		}
+
+		if len(records) == 0 {
+			break
+		}
+
+		if err := checkChunk(ctx, client, records, f, cfg, writer, p); err != nil {
+			return err
+		}
+
+		cp.Processed += len(records)
+		if err := saveCheckpoint(f.checkpoint, cp); err != nil {
+			return err
+		}
+
+		if !f.quiet {
+			p.report(e.stderr)
+		}
+	}
+
+	return nil
+}
+
+func readChunk(reader recordReader, size int) ([]record, error) {
+	records := make([]record, 0, size)
+	for len(records) < size {
+		r, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, r)
+	}
+
+	return records, nil
+}
+
+// checkChunk checks records and writes them in input order, nothing is written when ctx is cancelled
+func checkChunk(ctx context.Context, client *akismet.Client, records []record, f *batchFlags, cfg akismet.BatchConfig, writer recordWriter, p *progress) error {
+	errs := make([]error, len(records))
+	options := make([]akismet.Options, 0, len(records))
+	indexes := make([]int, 0, len(records))
+	for i, r := range records {
+		o, err := r.options(f.mapping, f.commentType)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		options = append(options, o)
+		indexes = append(indexes, i)
+	}
+
+	results := make([]*akismet.CheckResult, len(records))
+	for _, r := range client.CheckAll(ctx, options, cfg) {
+		results[indexes[r.Index]], errs[indexes[r.Index]] = r.Result, r.Err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("batch interrupted after %d records: %s", p.processed, err)
+	}
+
+	for i, r := range records {
+		if err := writer.Write(r, results[i], errs[i]); err != nil {
+			return err
+		}
+		p.add(results[i], errs[i])
+	}
+
+	return writer.Flush()
+}
+
+// options maps record columns to Options fields, columns are matched by JSON names of Options fields
+func (r record) options(mapping map[string]string, commentType string) (akismet.Options, error) {
+	fields := make(map[string]interface{}, len(r))
+	for column, value := range r {
+		if field, ok := mapping[column]; ok {
+			column = field
+		}
+
+		if number, ok := value.(json.Number); ok {
+			value = number.String()
+		}
+		fields[column] = value
+	}
+
+	o := akismet.Options{}
+	data, err := json.Marshal(fields)
+	if err == nil {
+		err = json.Unmarshal(data, &o)
+	}
+
+	if err != nil {
+		return o, fmt.Errorf("invalid record: %s", err)
+	}
+
+	if o.CommentType == "" {
+		o.CommentType = akismet.CommentType(commentType)
+	}
+
+	return o, nil
+}
+
+func annotate(result *akismet.CheckResult, err error) (verdict, guid, message string) {
+	if err != nil {
+		return "", "", err.Error()
+	}
+
+	return result.Verdict.String(), result.GUID, ""
+}
+
+type progress struct {
+	start     time.Time
+	processed int
+	checked   int
+	spam      int
+	errors    int
+}
+
+func (p *progress) add(result *akismet.CheckResult, err error) {
+	p.processed++
+	p.checked++
+	if err != nil {
+		p.errors++
+	} else if result.IsSpam() {
+		p.spam++
+	}
+}
+
+func (p *progress) report(w io.Writer) {
+	rate := float64(p.checked) / time.Since(p.start).Seconds()
+	fmt.Fprintf(w, "processed %d records (spam %d, errors %d, %.1f records/s)\n", p.processed, p.spam, p.errors, rate)
+}
+
+func loadCheckpoint(path string) (*checkpoint, error) {
+	cp := &checkpoint{}
+	if path == "" {
+		return cp, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file %s: %s", path, err)
+	}
+
+	return cp, nil
+}
+
+// saveCheckpoint replaces checkpoint file atomically
+func saveCheckpoint(path string, cp *checkpoint) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+type jsonlReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newJSONLReader(r io.Reader) *jsonlReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	return &jsonlReader{scanner: scanner}
+}
+
+func (r *jsonlReader) Read() (record, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(line))
+		decoder.UseNumber()
+		rec := record{}
+		if err := decoder.Decode(&rec); err != nil {
+			return nil, fmt.Errorf("line %d: %s", r.line, err)
+		}
+
+		return rec, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
+
+type jsonlWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func newJSONLWriter(w io.Writer) *jsonlWriter {
+	buffered := bufio.NewWriter(w)
+
+	return &jsonlWriter{w: buffered, enc: json.NewEncoder(buffered)}
+}
+
+func (w *jsonlWriter) Write(r record, result *akismet.CheckResult, err error) error {
+	verdict, guid, message := annotate(result, err)
+	r[columnVerdict], r[columnGUID], r[columnError] = verdict, guid, message
+
+	return w.enc.Encode(r)
+}
+
+func (w *jsonlWriter) Flush() error {
+	return w.w.Flush()
+}
+
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read CSV header: %s", err)
+	}
+
+	return &csvReader{reader: reader, header: header}, nil
+}
+
+func (r *csvReader) Read() (record, error) {
+	row, err := r.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	rec := make(record, len(row))
+	for i, value := range row {
+		rec[r.header[i]] = value
+	}
+
+	return rec, nil
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	header []string
+	err    error
+}
+
+func newCSVWriter(w io.Writer, header []string, writeHeader bool) *csvWriter {
+	writer := &csvWriter{w: csv.NewWriter(w), header: header}
+	if writeHeader {
+		writer.err = writer.w.Write(append(append([]string{}, header...), columnVerdict, columnGUID, columnError))
+	}
+
+	return writer
+}
+
+func (w *csvWriter) Write(r record, result *akismet.CheckResult, err error) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	row := make([]string, 0, len(w.header)+3)
+	for _, column := range w.header {
+		row = append(row, fmt.Sprint(r[column]))
+	}
+
+	verdict, guid, message := annotate(result, err)
+
+	return w.w.Write(append(row, verdict, guid, message))
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+
+	return w.w.Error()
+}
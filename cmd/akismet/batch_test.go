@@ -0,0 +1,105 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SebastianCzoch/akismet-go/akismettest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchJSONL(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+
+	stdin := `{"id": 1, "ip": "127.0.0.1", "user_agent": "curl", "body": "hello"}
+
+{"id": 2, "ip": "127.0.0.1", "user_agent": "curl", "comment_author": "` + akismettest.SpamAuthor + `"}
+{"id": 3, "user_agent": "curl"}
+`
+	code, stdout, stderr := runCommand(s, stdin, "batch", "-format", "jsonl", "-map", "ip=user_ip,body=comment_content", "-workers", "2", "-quiet")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "", stderr)
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	assert.Len(t, lines, 3)
+	assert.Contains(t, lines[0], `"akismet_verdict":"ham"`)
+	assert.Contains(t, lines[0], `"id":1`)
+	assert.Contains(t, lines[1], `"akismet_verdict":"spam"`)
+	assert.Contains(t, lines[2], `"akismet_verdict":""`)
+	assert.Contains(t, lines[2], "UserIP")
+
+	assert.Len(t, s.Requests(), 2)
+	for _, r := range s.Requests() {
+		if r.Form.Get("comment_author") == "" {
+			assert.Equal(t, "hello", r.Form.Get("comment_content"))
+		}
+	}
+}
+
+func TestBatchCSV(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "posts.csv")
+	assert.Nil(t, os.WriteFile(input, []byte("user_ip,user_agent,comment_author\n127.0.0.1,curl,john\n127.0.0.1,curl,"+akismettest.SpamAuthor+"\n"), 0644))
+
+	code, stdout, stderr := runCommand(s, "", "batch", "-type", "forum-post", "-workers", "1", input)
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "user_ip,user_agent,comment_author,akismet_verdict,akismet_guid,akismet_error\n"+
+		"127.0.0.1,curl,john,ham,akismettest-1,\n"+
+		"127.0.0.1,curl,viagra-test-123,spam,akismettest-2,\n", stdout)
+	assert.Contains(t, stderr, "processed 2 records (spam 1, errors 0")
+	assert.Equal(t, "forum-post", s.Requests()[0].Form.Get("comment_type"))
+}
+
+func TestBatchResume(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "posts.csv")
+	output := filepath.Join(dir, "out.csv")
+	checkpoint := filepath.Join(dir, "checkpoint.json")
+	assert.Nil(t, os.WriteFile(input, []byte("user_ip,user_agent\n127.0.0.1,first\n127.0.0.1,second\n127.0.0.1,third\n"), 0644))
+
+	code, _, _ := runCommand(s, "", "batch", "-chunk", "2", "-o", output, "-checkpoint", checkpoint, "-quiet", input)
+	assert.Equal(t, exitOK, code)
+	assert.Len(t, s.Requests(), 3)
+
+	data, err := os.ReadFile(checkpoint)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"processed":3}`, string(data))
+
+	assert.Nil(t, os.WriteFile(checkpoint, []byte(`{"processed":2}`), 0644))
+	assert.Nil(t, os.WriteFile(output, []byte("user_ip,user_agent,akismet_verdict,akismet_guid,akismet_error\n127.0.0.1,first,ham,a,\n127.0.0.1,second,ham,b,\n"), 0644))
+	s.Reset()
+
+	code, _, _ = runCommand(s, "", "batch", "-chunk", "2", "-o", output, "-checkpoint", checkpoint, "-quiet", input)
+	assert.Equal(t, exitOK, code)
+	assert.Len(t, s.Requests(), 1)
+	assert.Equal(t, "third", s.Requests()[0].Form.Get("user_agent"))
+
+	data, err = os.ReadFile(output)
+	assert.Nil(t, err)
+	assert.Equal(t, "user_ip,user_agent,akismet_verdict,akismet_guid,akismet_error\n"+
+		"127.0.0.1,first,ham,a,\n127.0.0.1,second,ham,b,\n127.0.0.1,third,ham,akismettest-1,\n", string(data))
+}
+
+func TestBatchUsage(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+
+	code, _, _ := runCommand(s, "", "batch")
+	assert.Equal(t, exitUsage, code)
+
+	code, _, _ = runCommand(s, "", "batch", "-format", "jsonl", "-map", "ip")
+	assert.Equal(t, exitUsage, code)
+
+	code, _, stderr := runCommand(s, "{", "batch", "-format", "jsonl")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "line 1")
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+type config struct {
+	APIKey  string `json:"api_key"`
+	Site    string `json:"site"`
+	BaseURL string `json:"base_url"`
+}
+
+// loadConfig reads config file (if path is not empty) and overrides it with environment
+func loadConfig(path string, getenv func(string) string) (*config, error) {
+	cfg := &config{}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %s", path, err)
+		}
+	}
+
+	for name, field := range map[string]*string{
+		"AKISMET_API_KEY":  &cfg.APIKey,
+		"AKISMET_SITE":     &cfg.Site,
+		"AKISMET_BASE_URL": &cfg.BaseURL,
+	} {
+		if value := getenv(name); value != "" {
+			*field = value
+		}
+	}
+
+	if cfg.APIKey == "" || cfg.Site == "" {
+		return nil, fmt.Errorf("API key and site are required, set AKISMET_API_KEY and AKISMET_SITE or use config file")
+	}
+
+	return cfg, nil
+}
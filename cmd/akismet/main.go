@@ -0,0 +1,238 @@
+// Command akismet checks and submits content to Akismet from the terminal
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/SebastianCzoch/akismet-go"
+)
+
+const usage = `Usage: akismet <command> [flags]
+
+Commands:
+  verify       check API key and site
+  check        check content, exit code reflects verdict
+  submit-spam  submit spam which was not caught
+  submit-ham   submit false positive
+  batch        check JSONL or CSV records and write them annotated with verdict
+
+Run "akismet <command> -h" to see flags of the command. Content can be passed
+with flags or as JSON document on stdin (-stdin), flags override stdin.
+
+Configuration, environment overrides config file:
+  AKISMET_API_KEY, AKISMET_SITE, AKISMET_BASE_URL
+  AKISMET_CONFIG or -config  JSON file with "api_key", "site" and "base_url"
+
+Exit codes: 0 ham or success, 1 error, 2 usage error, 3 spam, 4 discard
+`
+
+// Exit codes
+const (
+	exitOK      = 0
+	exitError   = 1
+	exitUsage   = 2
+	exitSpam    = 3
+	exitDiscard = 4
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr, os.Getenv))
+}
+
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer, getenv func(string) string) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	e := &env{stdin: stdin, stdout: stdout, stderr: stderr, getenv: getenv}
+	switch args[0] {
+	case "verify":
+		return e.verify(args[1:])
+	case "check":
+		return e.check(args[1:])
+	case "submit-spam", "submit-ham":
+		return e.submit(args[0], args[1:])
+	case "batch":
+		return e.batch(args[1:])
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	}
+
+	fmt.Fprintf(stderr, "akismet: unknown command %q\n\n%s", args[0], usage)
+	return exitUsage
+}
+
+// commonFlags are flags shared by all commands
+type commonFlags struct {
+	config  string
+	json    bool
+	timeout time.Duration
+}
+
+func (e *env) newFlagSet(name string) (*flag.FlagSet, *commonFlags) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	c := &commonFlags{}
+	fs.StringVar(&c.config, "config", e.getenv("AKISMET_CONFIG"), "JSON config file")
+	fs.BoolVar(&c.json, "json", false, "print JSON output")
+	fs.DurationVar(&c.timeout, "timeout", 10*time.Second, "request timeout")
+
+	return fs, c
+}
+
+func (e *env) client(c *commonFlags) (*akismet.Client, error) {
+	cfg, err := loadConfig(c.config, e.getenv)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []akismet.Option{
+		akismet.WithUserAgent("akismet-cli/" + akismet.LibraryVersion),
+		akismet.WithTimeout(c.timeout),
+	}
+	if cfg.BaseURL != "" {
+		opts = append(opts, akismet.WithBaseURL(cfg.BaseURL))
+	}
+
+	return akismet.NewClient(cfg.APIKey, cfg.Site, opts...), nil
+}
+
+func (e *env) verify(args []string) int {
+	fs, c := e.newFlagSet("verify")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	client, err := e.client(c)
+	if err != nil {
+		return e.fail(err)
+	}
+
+	err = client.VerifyKey(context.Background())
+	if err != nil && !errors.Is(err, akismet.ErrInvalidKey) {
+		return e.fail(err)
+	}
+
+	if c.json {
+		res := struct {
+			Valid bool   `json:"valid"`
+			Error string `json:"error,omitempty"`
+		}{Valid: err == nil}
+		if err != nil {
+			res.Error = err.Error()
+		}
+		e.printJSON(res)
+	} else if err == nil {
+		fmt.Fprintln(e.stdout, "valid")
+	} else {
+		fmt.Fprintf(e.stdout, "invalid: %s\n", err)
+	}
+
+	if err != nil {
+		return exitError
+	}
+
+	return exitOK
+}
+
+func (e *env) check(args []string) int {
+	fs, c := e.newFlagSet("check")
+	o, err := e.parseOptions(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+
+	client, err := e.client(c)
+	if err != nil {
+		return e.fail(err)
+	}
+
+	res, err := client.Check(*o)
+	if err != nil {
+		return e.fail(err)
+	}
+
+	if c.json {
+		e.printJSON(res)
+	} else {
+		fmt.Fprintln(e.stdout, res.Verdict)
+		printField(e.stdout, "guid", res.GUID)
+		printField(e.stdout, "pro-tip", res.ProTip)
+		printField(e.stdout, "debug-help", res.DebugHelp)
+		printField(e.stdout, "alert", res.AlertMsg)
+	}
+
+	switch res.Verdict {
+	case akismet.Ham:
+		return exitOK
+	case akismet.Discard:
+		return exitDiscard
+	}
+
+	return exitSpam
+}
+
+func (e *env) submit(command string, args []string) int {
+	fs, c := e.newFlagSet(command)
+	o, err := e.parseOptions(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+
+	client, err := e.client(c)
+	if err != nil {
+		return e.fail(err)
+	}
+
+	submit := client.SubmitSpam
+	if command == "submit-ham" {
+		submit = client.SubmitHam
+	}
+
+	if err := submit(*o); err != nil {
+		return e.fail(err)
+	}
+
+	if c.json {
+		e.printJSON(struct {
+			Submitted bool `json:"submitted"`
+		}{true})
+	} else {
+		fmt.Fprintln(e.stdout, "submitted")
+	}
+
+	return exitOK
+}
+
+func (e *env) fail(err error) int {
+	fmt.Fprintf(e.stderr, "akismet: %s\n", err)
+	return exitError
+}
+
+func (e *env) printJSON(v interface{}) {
+	enc := json.NewEncoder(e.stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+func printField(w io.Writer, name, value string) {
+	if value != "" {
+		fmt.Fprintf(w, "%s: %s\n", name, value)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SebastianCzoch/akismet-go"
+	"github.com/SebastianCzoch/akismet-go/akismettest"
+	"github.com/stretchr/testify/assert"
+)
+
+func runCommand(s *akismettest.Server, stdin string, args ...string) (int, string, string) {
+	environment := map[string]string{
+		"AKISMET_API_KEY":  "test_api_key",
+		"AKISMET_SITE":     "http://example.com",
+		"AKISMET_BASE_URL": s.URL,
+	}
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run(args, strings.NewReader(stdin), stdout, stderr, func(name string) string { return environment[name] })
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestVerify(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+
+	code, stdout, _ := runCommand(s, "", "verify")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "valid\n", stdout)
+
+	code, stdout, _ = runCommand(s, "", "verify", "-json")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "{\n  \"valid\": true\n}\n", stdout)
+}
+
+func TestVerifyInvalidKey(t *testing.T) {
+	s := akismettest.NewServer("other_api_key")
+	defer s.Close()
+
+	code, stdout, _ := runCommand(s, "", "verify")
+	assert.Equal(t, exitError, code)
+	assert.True(t, strings.HasPrefix(stdout, "invalid"))
+}
+
+func TestCheckExitCodes(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+
+	code, stdout, _ := runCommand(s, "", "check", "-ip", "127.0.0.1", "-user-agent", "curl", "-content", "hello")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "ham\nguid: akismettest-1\n", stdout)
+
+	code, stdout, _ = runCommand(s, "", "check", "-ip", "127.0.0.1", "-user-agent", "curl", "-author", akismettest.SpamAuthor)
+	assert.Equal(t, exitSpam, code)
+	assert.Equal(t, "spam\nguid: akismettest-2\n", stdout)
+
+	s.QueueVerdicts(akismettest.Discard)
+	code, stdout, _ = runCommand(s, "", "check", "-ip", "127.0.0.1", "-user-agent", "curl")
+	assert.Equal(t, exitDiscard, code)
+	assert.Equal(t, "discard\nguid: akismettest-3\npro-tip: discard\n", stdout)
+}
+
+func TestCheckStdinAndJSON(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+
+	stdin := `{"user_ip": "10.0.0.1", "user_agent": "curl", "comment_author": "` + akismettest.SpamAuthor + `", "comment_content": "buy now"}`
+	code, stdout, _ := runCommand(s, stdin, "check", "-stdin", "-json", "-ip", "127.0.0.1")
+	assert.Equal(t, exitSpam, code)
+
+	var result akismet.CheckResult
+	assert.Nil(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, akismet.Spam, result.Verdict)
+
+	form := s.Requests()[0].Form
+	assert.Equal(t, "127.0.0.1", form.Get("user_ip"))
+	assert.Equal(t, "buy now", form.Get("comment_content"))
+}
+
+func TestCheckInvalidStdin(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+
+	code, _, stderr := runCommand(s, "{", "check", "-stdin")
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, "invalid JSON on stdin")
+	assert.Len(t, s.Requests(), 0)
+}
+
+func TestCheckMissingUserIP(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+
+	code, _, stderr := runCommand(s, "", "check", "-user-agent", "curl")
+	assert.Equal(t, exitError, code)
+	assert.True(t, strings.HasPrefix(stderr, "akismet: "))
+}
+
+func TestSubmit(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+
+	code, stdout, _ := runCommand(s, "", "submit-spam", "-ip", "127.0.0.1", "-user-agent", "curl")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "submitted\n", stdout)
+
+	code, _, _ = runCommand(s, "", "submit-ham", "-ip", "127.0.0.1", "-user-agent", "curl")
+	assert.Equal(t, exitOK, code)
+
+	requests := s.Requests()
+	assert.Equal(t, "submit-spam", requests[0].Endpoint)
+	assert.Equal(t, "submit-ham", requests[1].Endpoint)
+}
+
+func TestUsage(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	getenv := func(string) string { return "" }
+
+	assert.Equal(t, exitUsage, run(nil, nil, stdout, stderr, getenv))
+	assert.Equal(t, exitUsage, run([]string{"unknown"}, nil, stdout, stderr, getenv))
+	assert.Equal(t, exitUsage, run([]string{"check", "-unknown"}, nil, stdout, stderr, getenv))
+	assert.Equal(t, exitError, run([]string{"verify"}, nil, stdout, stderr, getenv))
+	assert.Contains(t, stderr.String(), "API key and site are required")
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "akismet.json")
+	assert.Nil(t, os.WriteFile(path, []byte(`{"api_key": "file_key", "site": "http://file.example.com"}`), 0600))
+
+	cfg, err := loadConfig(path, func(name string) string {
+		if name == "AKISMET_SITE" {
+			return "http://env.example.com"
+		}
+		return ""
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "file_key", cfg.APIKey)
+	assert.Equal(t, "http://env.example.com", cfg.Site)
+
+	_, err = loadConfig(filepath.Join(t.TempDir(), "missing.json"), func(string) string { return "" })
+	assert.NotNil(t, err)
+}
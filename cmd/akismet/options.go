@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/SebastianCzoch/akismet-go"
+)
+
+// optionFlags are flags which set Options fields
+var optionFlags = []struct {
+	name  string
+	usage string
+	set   func(o *akismet.Options, value string)
+}{
+	{"ip", "IP address of the submitter (required)", func(o *akismet.Options, v string) { o.UserIP = v }},
+	{"user-agent", "user agent of the submitter (required)", func(o *akismet.Options, v string) { o.UserAgent = v }},
+	{"referrer", "HTTP referrer", func(o *akismet.Options, v string) { o.Referrer = v }},
+	{"permalink", "location of the entry the content was submitted to", func(o *akismet.Options, v string) { o.Permalink = v }},
+	{"type", "comment type, e.g. comment, forum-post, contact-form, signup", func(o *akismet.Options, v string) { o.CommentType = akismet.CommentType(v) }},
+	{"author", "author name", func(o *akismet.Options, v string) { o.Author = v }},
+	{"email", "author email", func(o *akismet.Options, v string) { o.AuthorEmail = v }},
+	{"url", "author URL", func(o *akismet.Options, v string) { o.AuthorURL = v }},
+	{"content", "submitted content", func(o *akismet.Options, v string) { o.Content = v }},
+	{"lang", "site languages, e.g. \"en, fr_ca\"", func(o *akismet.Options, v string) { o.Lang = v }},
+	{"role", "user role, administrator is never spam", func(o *akismet.Options, v string) { o.UserRole = v }},
+	{"test", "mark as test query", func(o *akismet.Options, v string) { o.IsTest = v }},
+}
+
+// parseOptions parses args and returns Options read from stdin (with -stdin) and flags
+func (e *env) parseOptions(fs *flag.FlagSet, args []string) (*akismet.Options, error) {
+	for _, f := range optionFlags {
+		fs.String(f.name, "", f.usage)
+	}
+	stdin := fs.Bool("stdin", false, "read content as JSON document from stdin")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	o := &akismet.Options{}
+	if *stdin {
+		if err := json.NewDecoder(e.stdin).Decode(o); err != nil {
+			err = fmt.Errorf("invalid JSON on stdin: %s", err)
+			fmt.Fprintf(e.stderr, "akismet: %s\n", err)
+			return nil, err
+		}
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		for _, of := range optionFlags {
+			if of.name == f.Name {
+				of.set(o, f.Value.String())
+			}
+		}
+	})
+
+	return o, nil
+}
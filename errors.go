@@ -0,0 +1,65 @@
+package akismet
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Errors returned by Client, use errors.Is to check for them
+var (
+	ErrInvalidKey         = errors.New("invalid key or blog")
+	ErrInvalidRequest     = errors.New("bad request")
+	ErrMissingUserIP      = errors.New("filed UserIP can not be empty, it is required")
+	ErrMissingUserAgent   = errors.New("filed UserAgent can not be empty, it is required")
+	ErrInvalidParameter   = errors.New("invalid parameter")
+	ErrUnexpectedStatus   = errors.New("something went wrong, HTTP status code is not equals 200")
+	ErrUnexpectedResponse = errors.New("internal error")
+	ErrRateLimited        = errors.New("rate limit exceeded")
+	ErrCircuitOpen        = errors.New("circuit breaker is open, Akismet API is unavailable")
+)
+
+// APIError is returned when Akismet API responded, but the response is not a success.
+// It wraps one of sentinel errors, so errors.Is(err, ErrInvalidRequest) works as well.
+type APIError struct {
+	Endpoint   string
+	StatusCode int
+	Body       string
+	DebugHelp  string
+	AlertCode  string
+	AlertMsg   string
+	Err        error
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s (endpoint %s, HTTP status code %d)", e.Err, e.Endpoint, e.StatusCode)
+	if e.DebugHelp != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.DebugHelp)
+	}
+
+	return msg
+}
+
+// Unwrap returns sentinel error wrapped by APIError
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+type apiResponse struct {
+	endpoint   string
+	statusCode int
+	header     http.Header
+	body       string
+}
+
+func (r *apiResponse) error(err error) *APIError {
+	return &APIError{
+		Endpoint:   r.endpoint,
+		StatusCode: r.statusCode,
+		Body:       r.body,
+		DebugHelp:  r.header.Get("X-akismet-debug-help"),
+		AlertCode:  r.header.Get("X-akismet-alert-code"),
+		AlertMsg:   r.header.Get("X-akismet-alert-msg"),
+		Err:        err,
+	}
+}
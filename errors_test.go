@@ -0,0 +1,86 @@
+package akismet
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSpamInvalidAPIError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/comment-check", func(req *http.Request) (*http.Response, error) {
+		res := httpmock.NewStringResponse(200, "invalid")
+		res.Header.Set("X-akismet-debug-help", "Empty \"blog\" value")
+		res.Header.Set("X-akismet-alert-code", "10001")
+		res.Header.Set("X-akismet-alert-msg", "Your site is using an expired key")
+		return res, nil
+	})
+
+	client := NewClient("test_api_key", "test_site")
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	_, err := client.IsSpam(options)
+	assert.True(t, errors.Is(err, ErrInvalidRequest))
+
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, "comment-check", apiErr.Endpoint)
+		assert.Equal(t, 200, apiErr.StatusCode)
+		assert.Equal(t, "invalid", apiErr.Body)
+		assert.Equal(t, "Empty \"blog\" value", apiErr.DebugHelp)
+		assert.Equal(t, "10001", apiErr.AlertCode)
+		assert.Equal(t, "Your site is using an expired key", apiErr.AlertMsg)
+	}
+	assert.EqualError(t, err, "bad request (endpoint comment-check, HTTP status code 200): Empty \"blog\" value")
+}
+
+func TestSubmitUnexpectedStatusAPIError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/submit-ham", httpmock.NewStringResponder(503, "unavailable"))
+
+	client := NewClient("test_api_key", "test_site")
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	err := client.SubmitHam(options)
+	assert.True(t, errors.Is(err, ErrUnexpectedStatus))
+
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, "submit-ham", apiErr.Endpoint)
+		assert.Equal(t, 503, apiErr.StatusCode)
+		assert.Equal(t, "unavailable", apiErr.Body)
+	}
+}
+
+func TestSubmitUnexpectedResponse(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://test_api_key.rest.akismet.com/1.1/submit-spam", httpmock.NewStringResponder(200, "what?"))
+
+	client := NewClient("test_api_key", "test_site")
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	err := client.SubmitSpam(options)
+	assert.True(t, errors.Is(err, ErrUnexpectedResponse))
+}
+
+func TestVeryfiClientInvalidKey(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", "https://rest.akismet.com/1.1/verify-key?blog=test_site&key=test_api_key", httpmock.NewStringResponder(200, "invalid"))
+
+	client := NewClient("test_api_key", "test_site")
+	err := client.VeryfiClient()
+	assert.True(t, errors.Is(err, ErrInvalidKey))
+}
+
+func TestMissingRequiredOptionsSentinels(t *testing.T) {
+	client := NewClient("test_api_key", "test_site")
+	_, err := client.IsSpam(Options{})
+	assert.True(t, errors.Is(err, ErrMissingUserIP))
+
+	_, err = client.IsSpam(Options{UserIP: "127.0.0.1"})
+	assert.True(t, errors.Is(err, ErrMissingUserAgent))
+}
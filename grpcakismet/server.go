@@ -0,0 +1,286 @@
+// Package grpcakismet implements akismetpb.AkismetServer with akismet.Client
+package grpcakismet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/SebastianCzoch/akismet-go"
+	"github.com/SebastianCzoch/akismet-go/akismetpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server delegates Akismet service calls to Client, register it with akismetpb.RegisterAkismetServer
+type Server struct {
+	akismetpb.UnimplementedAkismetServer
+
+	client  *akismet.Client
+	workers int
+}
+
+// NewServer creates Server, workers is a number of concurrent checks of a single stream, default is 4.
+// Use akismet.WithRateLimit to limit requests of all calls.
+func NewServer(client *akismet.Client, workers int) *Server {
+	if workers <= 0 {
+		workers = 4
+	}
+
+	return &Server{client: client, workers: workers}
+}
+
+// VerifyKey implements akismetpb.AkismetServer
+func (s *Server) VerifyKey(ctx context.Context, _ *akismetpb.VerifyKeyRequest) (*akismetpb.VerifyKeyResponse, error) {
+	err := s.client.VerifyKey(ctx)
+	if errors.Is(err, akismet.ErrInvalidKey) {
+		return &akismetpb.VerifyKeyResponse{Valid: false}, nil
+	}
+
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &akismetpb.VerifyKeyResponse{Valid: true}, nil
+}
+
+// Check implements akismetpb.AkismetServer
+func (s *Server) Check(ctx context.Context, r *akismetpb.CheckRequest) (*akismetpb.CheckResponse, error) {
+	o, err := options(r.GetOptions())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	result, err := s.client.CheckContext(ctx, o)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &akismetpb.CheckResponse{Result: checkResult(result)}, nil
+}
+
+// SubmitSpam implements akismetpb.AkismetServer
+func (s *Server) SubmitSpam(ctx context.Context, r *akismetpb.SubmitRequest) (*akismetpb.SubmitResponse, error) {
+	o, err := options(r.GetOptions())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	if err := s.client.SubmitSpamContext(ctx, o); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &akismetpb.SubmitResponse{}, nil
+}
+
+// SubmitHam implements akismetpb.AkismetServer
+func (s *Server) SubmitHam(ctx context.Context, r *akismetpb.SubmitRequest) (*akismetpb.SubmitResponse, error) {
+	o, err := options(r.GetOptions())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	if err := s.client.SubmitHamContext(ctx, o); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &akismetpb.SubmitResponse{}, nil
+}
+
+// CheckBatch implements akismetpb.AkismetServer with Client.CheckBatch
+func (s *Server) CheckBatch(r *akismetpb.CheckBatchRequest, stream akismetpb.Akismet_CheckBatchServer) error {
+	batch := make([]akismet.Options, len(r.GetOptions()))
+	for i, o := range r.GetOptions() {
+		var err error
+		if batch[i], err = options(o); err != nil {
+			return statusError(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var err error
+	for result := range s.client.CheckBatch(ctx, batch, akismet.BatchConfig{Workers: s.workers}) {
+		if err != nil {
+			continue
+		}
+
+		response := &akismetpb.CheckBatchResponse{Index: int32(result.Index)}
+		if result.Err != nil {
+			response.Error = errorMessage(result.Err)
+		} else {
+			response.Result = checkResult(result.Result)
+		}
+
+		// remaining results are drained so CheckBatch workers can finish
+		if err = stream.Send(response); err != nil {
+			cancel()
+		}
+	}
+
+	return err
+}
+
+// CheckStream implements akismetpb.AkismetServer, up to workers requests are checked concurrently
+func (s *Server) CheckStream(stream akismetpb.Akismet_CheckStreamServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		sendErr error
+	)
+	workers := make(chan struct{}, s.workers)
+
+	send := func(response *akismetpb.CheckStreamResponse) {
+		mu.Lock()
+		defer mu.Unlock()
+		if sendErr != nil {
+			return
+		}
+
+		if sendErr = stream.Send(response); sendErr != nil {
+			cancel()
+		}
+	}
+
+	var recvErr error
+	for {
+		r, err := stream.Recv()
+		if err != nil {
+			if err != io.EOF {
+				recvErr = err
+			}
+			break
+		}
+
+		select {
+		case workers <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(r *akismetpb.CheckStreamRequest) {
+			defer wg.Done()
+			defer func() { <-workers }()
+
+			response := &akismetpb.CheckStreamResponse{Id: r.GetId()}
+			o, err := options(r.GetOptions())
+			if err != nil {
+				response.Error = errorMessage(err)
+				send(response)
+				return
+			}
+
+			result, err := s.client.CheckContext(ctx, o)
+			if err != nil {
+				response.Error = errorMessage(err)
+			} else {
+				response.Result = checkResult(result)
+			}
+			send(response)
+		}(r)
+	}
+
+	wg.Wait()
+	if recvErr != nil {
+		return recvErr
+	}
+
+	if sendErr != nil {
+		return sendErr
+	}
+
+	return ctx.Err()
+}
+
+func options(o *akismetpb.Options) (akismet.Options, error) {
+	result := akismet.Options{
+		UserIP:            o.GetUserIp(),
+		UserAgent:         o.GetUserAgent(),
+		Referrer:          o.GetReferrer(),
+		Permalink:         o.GetPermalink(),
+		CommentType:       akismet.CommentType(o.GetCommentType()),
+		Author:            o.GetCommentAuthor(),
+		AuthorEmail:       o.GetCommentAuthorEmail(),
+		AuthorURL:         o.GetCommentAuthorUrl(),
+		Content:           o.GetCommentContent(),
+		Lang:              o.GetBlogLang(),
+		Charset:           o.GetBlogCharset(),
+		UserRole:          o.GetUserRole(),
+		HoneypotFieldName: o.GetHoneypotFieldName(),
+		HoneypotValue:     o.GetHoneypotValue(),
+		RecheckReason:     o.GetRecheckReason(),
+		CommentContext:    o.GetCommentContext(),
+		CommentParent:     o.GetCommentParent(),
+		ServerEnv:         o.GetServerEnv(),
+	}
+
+	if o.GetCommentDate() != nil {
+		if err := result.SetCreated(o.GetCommentDate().AsTime()); err != nil {
+			return result, fmt.Errorf("%w: comment_date: %v", akismet.ErrInvalidParameter, err)
+		}
+	}
+
+	if o.GetCommentPostModified() != nil {
+		if err := result.SetModified(o.GetCommentPostModified().AsTime()); err != nil {
+			return result, fmt.Errorf("%w: comment_post_modified: %v", akismet.ErrInvalidParameter, err)
+		}
+	}
+
+	result.SetTest(o.GetIsTest())
+
+	return result, nil
+}
+
+var verdicts = map[akismet.Verdict]akismetpb.Verdict{
+	akismet.Ham:     akismetpb.Verdict_VERDICT_HAM,
+	akismet.Spam:    akismetpb.Verdict_VERDICT_SPAM,
+	akismet.Discard: akismetpb.Verdict_VERDICT_DISCARD,
+	akismet.Hold:    akismetpb.Verdict_VERDICT_HOLD,
+}
+
+func checkResult(r *akismet.CheckResult) *akismetpb.CheckResult {
+	return &akismetpb.CheckResult{
+		Verdict:   verdicts[r.Verdict],
+		ProTip:    r.ProTip,
+		Guid:      r.GUID,
+		DebugHelp: r.DebugHelp,
+		AlertCode: r.AlertCode,
+		AlertMsg:  r.AlertMsg,
+		Fallback:  r.Fallback,
+		CacheHit:  r.CacheHit,
+	}
+}
+
+// statusError maps client errors to gRPC status codes
+func statusError(err error) error {
+	code := codes.Unavailable
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.Is(err, akismet.ErrMissingUserIP), errors.Is(err, akismet.ErrMissingUserAgent), errors.Is(err, akismet.ErrInvalidRequest),
+		errors.Is(err, akismet.ErrInvalidParameter):
+		code = codes.InvalidArgument
+	case errors.Is(err, akismet.ErrInvalidKey):
+		code = codes.FailedPrecondition
+	case errors.Is(err, akismet.ErrRateLimited):
+		code = codes.ResourceExhausted
+	}
+
+	return status.Error(code, err.Error())
+}
+
+func errorMessage(err error) *akismetpb.Error {
+	st := status.Convert(statusError(err))
+
+	return &akismetpb.Error{Code: int32(st.Code()), Message: st.Message()}
+}
@@ -0,0 +1,177 @@
+package grpcakismet
+
+import (
+	"context"
+	"io"
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/SebastianCzoch/akismet-go"
+	"github.com/SebastianCzoch/akismet-go/akismetpb"
+	"github.com/SebastianCzoch/akismet-go/akismettest"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestClient(t *testing.T, s *akismettest.Server, apiKey string) akismetpb.AkismetClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	client := akismet.NewClient(apiKey, "http://example.com", akismet.WithBaseURL(s.URL))
+	akismetpb.RegisterAkismetServer(server, NewServer(client, 2))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.Nil(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return akismetpb.NewAkismetClient(conn)
+}
+
+func spamOptions() *akismetpb.Options {
+	return &akismetpb.Options{UserIp: "127.0.0.1", UserAgent: "curl", CommentAuthor: akismettest.SpamAuthor}
+}
+
+func TestVerifyKey(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+
+	response, err := newTestClient(t, s, "test_api_key").VerifyKey(context.Background(), &akismetpb.VerifyKeyRequest{})
+	assert.Nil(t, err)
+	assert.True(t, response.GetValid())
+
+	response, err = newTestClient(t, s, "wrong_key").VerifyKey(context.Background(), &akismetpb.VerifyKeyRequest{})
+	assert.Nil(t, err)
+	assert.False(t, response.GetValid())
+}
+
+func TestCheck(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+	client := newTestClient(t, s, "test_api_key")
+
+	o := spamOptions()
+	o.CommentDate = timestamppb.New(time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC))
+	o.IsTest = true
+	o.CommentContext = []string{"cooking", "recipes"}
+	response, err := client.Check(context.Background(), &akismetpb.CheckRequest{Options: o})
+	assert.Nil(t, err)
+	assert.Equal(t, akismetpb.Verdict_VERDICT_SPAM, response.GetResult().GetVerdict())
+	assert.Equal(t, "akismettest-1", response.GetResult().GetGuid())
+
+	form := s.Requests()[0].Form
+	assert.Equal(t, akismettest.SpamAuthor, form.Get("comment_author"))
+	assert.Equal(t, "1451703845", form.Get("comment_date_gmt"))
+	assert.Equal(t, "true", form.Get("is_test"))
+	assert.Equal(t, []string{"cooking", "recipes"}, form["comment_context[]"])
+
+	_, err = client.Check(context.Background(), &akismetpb.CheckRequest{Options: &akismetpb.Options{UserAgent: "curl"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	o = spamOptions()
+	o.ServerEnv = map[string]string{"api_key": "evil"}
+	_, err = client.Check(context.Background(), &akismetpb.CheckRequest{Options: o})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	o = spamOptions()
+	o.CommentPostModified = timestamppb.New(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))
+	_, err = client.Check(context.Background(), &akismetpb.CheckRequest{Options: o})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Len(t, s.Requests(), 1)
+}
+
+func TestSubmit(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+	client := newTestClient(t, s, "test_api_key")
+
+	_, err := client.SubmitSpam(context.Background(), &akismetpb.SubmitRequest{Options: spamOptions()})
+	assert.Nil(t, err)
+	_, err = client.SubmitHam(context.Background(), &akismetpb.SubmitRequest{Options: spamOptions()})
+	assert.Nil(t, err)
+
+	requests := s.Requests()
+	assert.Equal(t, "submit-spam", requests[0].Endpoint)
+	assert.Equal(t, "submit-ham", requests[1].Endpoint)
+}
+
+func TestCheckBatch(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+
+	stream, err := newTestClient(t, s, "test_api_key").CheckBatch(context.Background(), &akismetpb.CheckBatchRequest{
+		Options: []*akismetpb.Options{
+			{UserIp: "127.0.0.1", UserAgent: "curl"},
+			spamOptions(),
+			{UserAgent: "curl"},
+		},
+	})
+	assert.Nil(t, err)
+
+	responses := map[int32]*akismetpb.CheckBatchResponse{}
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		responses[response.GetIndex()] = response
+	}
+
+	assert.Len(t, responses, 3)
+	assert.Equal(t, akismetpb.Verdict_VERDICT_HAM, responses[0].GetResult().GetVerdict())
+	assert.Equal(t, akismetpb.Verdict_VERDICT_SPAM, responses[1].GetResult().GetVerdict())
+	assert.Nil(t, responses[2].GetResult())
+	assert.Equal(t, int32(codes.InvalidArgument), responses[2].GetError().GetCode())
+}
+
+func TestCheckStream(t *testing.T) {
+	s := akismettest.NewServer("test_api_key")
+	defer s.Close()
+
+	stream, err := newTestClient(t, s, "test_api_key").CheckStream(context.Background())
+	assert.Nil(t, err)
+
+	assert.Nil(t, stream.Send(&akismetpb.CheckStreamRequest{Id: "a", Options: &akismetpb.Options{UserIp: "127.0.0.1", UserAgent: "curl"}}))
+	assert.Nil(t, stream.Send(&akismetpb.CheckStreamRequest{Id: "b", Options: spamOptions()}))
+	assert.Nil(t, stream.Send(&akismetpb.CheckStreamRequest{Id: "c", Options: &akismetpb.Options{UserIp: "127.0.0.1"}}))
+	assert.Nil(t, stream.CloseSend())
+
+	var ids []string
+	verdicts := map[string]akismetpb.Verdict{}
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		ids = append(ids, response.GetId())
+		verdicts[response.GetId()] = response.GetResult().GetVerdict()
+		if response.GetId() == "c" {
+			assert.Equal(t, int32(codes.InvalidArgument), response.GetError().GetCode())
+		}
+	}
+
+	sort.Strings(ids)
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+	assert.Equal(t, akismetpb.Verdict_VERDICT_HAM, verdicts["a"])
+	assert.Equal(t, akismetpb.Verdict_VERDICT_SPAM, verdicts["b"])
+}
+
+func TestStatusError(t *testing.T) {
+	assert.Equal(t, codes.ResourceExhausted, status.Code(statusError(akismet.ErrRateLimited)))
+	assert.Equal(t, codes.Unavailable, status.Code(statusError(akismet.ErrCircuitOpen)))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(statusError(akismet.ErrInvalidKey)))
+	assert.Equal(t, codes.Canceled, status.Code(statusError(context.Canceled)))
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(statusError(context.DeadlineExceeded)))
+}
@@ -0,0 +1,128 @@
+package akismet
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// DefaultRedactedParams are request parameters with personal data, they are redacted in logs by default
+var DefaultRedactedParams = []string{
+	"user_ip",
+	"user_agent",
+	"referrer",
+	"comment_author",
+	"comment_author_email",
+	"comment_author_url",
+	"comment_content",
+	"comment_context[]",
+}
+
+// WithLogger sets logger which logs requests (at debug level), responses (at debug level)
+// and failures (at warn level). API key, server environment and honeypot value are always
+// redacted, personal data parameters are redacted according to WithLogRedaction.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithLogRedaction sets request parameters redacted in logs, replacing DefaultRedactedParams
+func WithLogRedaction(params ...string) Option {
+	return func(c *Client) {
+		c.redactedParams = params
+	}
+}
+
+func (c *Client) logRequest(ctx context.Context, endpoint *Endpoint, address url.URL, form string) {
+	if c.logger == nil {
+		return
+	}
+
+	address.RawQuery = ""
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "akismet request",
+		slog.String("endpoint", endpoint.Path),
+		slog.String("method", endpoint.Method),
+		slog.String("url", c.redactKey(address.String())),
+		slog.String("params", c.redactParams(form)),
+	)
+}
+
+func (c *Client) logResponse(ctx context.Context, r *apiResponse, latency time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "akismet response",
+		slog.String("endpoint", r.endpoint),
+		slog.Int("status", r.statusCode),
+		slog.Duration("latency", latency),
+		slog.String("debug_help", r.header.Get("X-akismet-debug-help")),
+		slog.String("alert_code", r.header.Get("X-akismet-alert-code")),
+		slog.String("alert_msg", r.header.Get("X-akismet-alert-msg")),
+	)
+}
+
+func (c *Client) logFailure(ctx context.Context, endpoint *Endpoint, err error, latency time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelWarn, "akismet request failed",
+		slog.String("endpoint", endpoint.Path),
+		slog.Duration("latency", latency),
+		slog.String("error", c.redactKey(err.Error())),
+	)
+}
+
+func (c *Client) logVerdict(ctx context.Context, result *CheckResult) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "akismet verdict",
+		slog.String("verdict", result.Verdict.String()),
+		slog.String("guid", result.GUID),
+		slog.String("pro_tip", result.ProTip),
+		slog.Bool("fallback", result.Fallback),
+		slog.Bool("cache_hit", result.CacheHit),
+	)
+}
+
+func (c *Client) redactKey(s string) string {
+	if c.apiKey == "" {
+		return s
+	}
+
+	return strings.Replace(s, c.apiKey, redacted, -1)
+}
+
+func (c *Client) redactParams(form string) string {
+	v, err := url.ParseQuery(form)
+	if err != nil {
+		return redacted
+	}
+
+	honeypot := v.Get("honeypot_field_name")
+	for name := range v {
+		if name == "api_key" || name == "key" || name == honeypot || serverEnvPattern.MatchString(name) || c.isRedacted(name) {
+			v[name] = []string{redacted}
+		}
+	}
+
+	return c.redactKey(v.Encode())
+}
+
+func (c *Client) isRedacted(param string) bool {
+	for _, p := range c.redactedParams {
+		if p == param {
+			return true
+		}
+	}
+
+	return false
+}
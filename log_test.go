@@ -0,0 +1,97 @@
+package akismet
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+func TestLoggerRedactsKeyAndPersonalData(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://secret_api_key.rest.akismet.com/1.1/comment-check", func(req *http.Request) (*http.Response, error) {
+		res := httpmock.NewStringResponse(200, "true")
+		res.Header.Set("X-akismet-guid", "abc123")
+		res.Header.Set("X-akismet-debug-help", "some help")
+		return res, nil
+	})
+
+	var buf bytes.Buffer
+	client := NewClient("secret_api_key", "test_site", WithLogger(newTestLogger(&buf)))
+	options := Options{
+		UserIP:            "192.0.2.1",
+		UserAgent:         "TestUserAgent",
+		AuthorEmail:       "john@example.com",
+		Content:           "Buy cheap watches",
+		Permalink:         "http://example.com/post",
+		CommentContext:    []string{"private-topic"},
+		HoneypotFieldName: "website2",
+		HoneypotValue:     "bot-filled-value",
+		ServerEnv: map[string]string{
+			"HTTP_X_FORWARDED_FOR": "198.51.100.7",
+			"HTTP_COOKIE":          "session=s3cr3t",
+			"REMOTE_ADDR":          "203.0.113.9",
+		},
+	}
+	_, err := client.Check(options)
+	assert.Nil(t, err)
+
+	out := buf.String()
+	assert.NotContains(t, out, "secret_api_key")
+	assert.NotContains(t, out, "192.0.2.1")
+	assert.NotContains(t, out, "john@example.com")
+	assert.NotContains(t, out, "watches")
+	assert.NotContains(t, out, "private-topic")
+	assert.NotContains(t, out, "bot-filled-value")
+	assert.NotContains(t, out, "198.51.100.7")
+	assert.NotContains(t, out, "s3cr3t")
+	assert.NotContains(t, out, "203.0.113.9")
+	assert.Contains(t, out, "honeypot_field_name=website2")
+	assert.Contains(t, out, "example.com%2Fpost")
+	assert.Contains(t, out, "akismet request")
+	assert.Contains(t, out, "endpoint=comment-check")
+	assert.Contains(t, out, "status=200")
+	assert.Contains(t, out, `debug_help="some help"`)
+	assert.Contains(t, out, "verdict=spam")
+	assert.Contains(t, out, "guid=abc123")
+}
+
+func TestLoggerCustomRedaction(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("POST", "https://secret_api_key.rest.akismet.com/1.1/submit-ham", httpmock.NewStringResponder(200, SubmitResponseContentOK))
+
+	var buf bytes.Buffer
+	client := NewClient("secret_api_key", "test_site", WithLogger(newTestLogger(&buf)), WithLogRedaction("comment_content"))
+	options := Options{UserIP: "192.0.2.1", UserAgent: "TestUserAgent", Content: "Buy cheap watches", ServerEnv: map[string]string{"REMOTE_ADDR": "203.0.113.9"}}
+	assert.Nil(t, client.SubmitHam(options))
+
+	out := buf.String()
+	assert.NotContains(t, out, "watches")
+	assert.NotContains(t, out, "203.0.113.9")
+	assert.Contains(t, out, "192.0.2.1")
+}
+
+func TestLoggerFailure(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var buf bytes.Buffer
+	client := NewClient("secret_api_key", "test_site", WithLogger(newTestLogger(&buf)))
+	options := Options{UserIP: "192.0.2.1", UserAgent: "TestUserAgent"}
+	_, err := client.Check(options)
+	assert.Error(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "level=WARN")
+	assert.Contains(t, out, "akismet request failed")
+	assert.NotContains(t, out, "secret_api_key")
+}
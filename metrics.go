@@ -0,0 +1,136 @@
+package akismet
+
+import (
+	"errors"
+	"expvar"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Metrics receives instrumentation events from Client. Implement it to export
+// metrics to Prometheus or another system, ExpvarMetrics is a dependency-free implementation.
+type Metrics interface {
+	// ObserveRequest is called after every API call (including retries) with endpoint path,
+	// HTTP status code (0 when no response was received), error and latency
+	ObserveRequest(endpoint string, statusCode int, err error, latency time.Duration)
+	// ObserveVerdict is called for every result returned by Check
+	ObserveVerdict(result *CheckResult)
+}
+
+// WithMetrics sets instrumentation hook
+func WithMetrics(m Metrics) Option {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
+// DefaultLatencyBuckets are upper bounds (in seconds) of latency histogram buckets
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// ExpvarMetrics publishes metrics with expvar:
+//
+//	requests         requests count per endpoint
+//	errors           errors count per endpoint
+//	latency_seconds  cumulative histogram per endpoint (le_<bound>, le_inf, sum, count)
+//	verdicts         results count per verdict, fallback and cache hits
+type ExpvarMetrics struct {
+	Map *expvar.Map
+
+	buckets  []float64
+	requests *expvar.Map
+	errors   *expvar.Map
+	latency  *expvar.Map
+	verdicts *expvar.Map
+
+	mu sync.Mutex
+}
+
+// NewExpvarMetrics creates ExpvarMetrics and publishes it under name,
+// like expvar.Publish it panics when name is already used
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	m := &ExpvarMetrics{
+		Map:      new(expvar.Map).Init(),
+		buckets:  DefaultLatencyBuckets,
+		requests: new(expvar.Map).Init(),
+		errors:   new(expvar.Map).Init(),
+		latency:  new(expvar.Map).Init(),
+		verdicts: new(expvar.Map).Init(),
+	}
+	m.Map.Set("requests", m.requests)
+	m.Map.Set("errors", m.errors)
+	m.Map.Set("latency_seconds", m.latency)
+	m.Map.Set("verdicts", m.verdicts)
+
+	for _, endpoint := range apiEndpoints {
+		m.requests.Add(endpoint.Path, 0)
+		m.errors.Add(endpoint.Path, 0)
+		m.latency.Set(endpoint.Path, new(expvar.Map).Init())
+	}
+
+	expvar.Publish(name, m.Map)
+
+	return m
+}
+
+// ObserveRequest implements Metrics
+func (m *ExpvarMetrics) ObserveRequest(endpoint string, statusCode int, err error, latency time.Duration) {
+	m.requests.Add(endpoint, 1)
+	if err != nil {
+		m.errors.Add(endpoint, 1)
+	}
+
+	h := m.histogram(endpoint)
+	seconds := latency.Seconds()
+	for _, bound := range m.buckets {
+		if seconds <= bound {
+			h.Add("le_"+strconv.FormatFloat(bound, 'g', -1, 64), 1)
+		}
+	}
+	h.Add("le_inf", 1)
+	h.AddFloat("sum", seconds)
+	h.Add("count", 1)
+}
+
+// histogram returns latency histogram of endpoint, histograms of endpoints
+// with custom paths are created on first use
+func (m *ExpvarMetrics) histogram(endpoint string) *expvar.Map {
+	if h, ok := m.latency.Get(endpoint).(*expvar.Map); ok {
+		return h
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.latency.Get(endpoint).(*expvar.Map)
+	if !ok {
+		h = new(expvar.Map).Init()
+		m.latency.Set(endpoint, h)
+	}
+
+	return h
+}
+
+// ObserveVerdict implements Metrics
+func (m *ExpvarMetrics) ObserveVerdict(result *CheckResult) {
+	m.verdicts.Add(result.Verdict.String(), 1)
+	if result.Fallback {
+		m.verdicts.Add("fallback", 1)
+	}
+	if result.CacheHit {
+		m.verdicts.Add("cache_hit", 1)
+	}
+}
+
+// statusCode returns HTTP status code of response or error, 0 when no response was received
+func statusCode(r *apiResponse, err error) int {
+	if r != nil {
+		return r.statusCode
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+
+	return 0
+}
@@ -0,0 +1,109 @@
+package akismet
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedRequest struct {
+	endpoint   string
+	statusCode int
+	err        error
+}
+
+type testMetrics struct {
+	requests []recordedRequest
+	verdicts []Verdict
+}
+
+func (m *testMetrics) ObserveRequest(endpoint string, statusCode int, err error, latency time.Duration) {
+	m.requests = append(m.requests, recordedRequest{endpoint, statusCode, err})
+}
+
+func (m *testMetrics) ObserveVerdict(result *CheckResult) {
+	m.verdicts = append(m.verdicts, result.Verdict)
+}
+
+func TestMetricsHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/1.1/submit-spam" {
+			w.WriteHeader(500)
+			return
+		}
+		w.Write([]byte("true"))
+	}))
+	defer server.Close()
+
+	m := &testMetrics{}
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithMetrics(m))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	_, err := client.Check(options)
+	assert.Nil(t, err)
+	assert.Error(t, client.SubmitSpam(options))
+
+	if assert.Len(t, m.requests, 2) {
+		assert.Equal(t, recordedRequest{"comment-check", 200, nil}, m.requests[0])
+		assert.Equal(t, "submit-spam", m.requests[1].endpoint)
+		assert.Equal(t, 500, m.requests[1].statusCode)
+		assert.True(t, errors.Is(m.requests[1].err, ErrUnexpectedStatus))
+	}
+	assert.Equal(t, []Verdict{Spam}, m.verdicts)
+}
+
+func TestExpvarMetrics(t *testing.T) {
+	m := NewExpvarMetrics("akismet_test_metrics")
+	assert.True(t, expvar.Get("akismet_test_metrics") == m.Map)
+
+	m.ObserveRequest("comment-check", 200, nil, 300*time.Millisecond)
+	m.ObserveRequest("comment-check", 0, errors.New("timeout"), 20*time.Second)
+	m.ObserveVerdict(&CheckResult{Verdict: Spam})
+	m.ObserveVerdict(&CheckResult{Verdict: Ham, Fallback: true})
+	m.ObserveVerdict(&CheckResult{Verdict: Spam, CacheHit: true})
+
+	var exported map[string]map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(m.Map.String()), &exported))
+	assert.Equal(t, float64(2), exported["requests"]["comment-check"])
+	assert.Equal(t, float64(0), exported["requests"]["submit-ham"])
+	assert.Equal(t, float64(1), exported["errors"]["comment-check"])
+	assert.Equal(t, float64(2), exported["verdicts"]["spam"])
+	assert.Equal(t, float64(1), exported["verdicts"]["fallback"])
+	assert.Equal(t, float64(1), exported["verdicts"]["cache_hit"])
+
+	latency := exported["latency_seconds"]["comment-check"].(map[string]interface{})
+	assert.Nil(t, latency["le_0.25"])
+	assert.Equal(t, float64(1), latency["le_0.5"])
+	assert.Equal(t, float64(1), latency["le_10"])
+	assert.Equal(t, float64(2), latency["le_inf"])
+	assert.Equal(t, float64(2), latency["count"])
+	assert.InDelta(t, 20.3, latency["sum"], 0.001)
+}
+
+func TestExpvarMetricsConcurrent(t *testing.T) {
+	m := NewExpvarMetrics("akismet_test_metrics_concurrent")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.ObserveRequest("submit-spam", 200, nil, time.Millisecond)
+			m.ObserveRequest("custom-check", 200, nil, time.Millisecond)
+		}()
+	}
+	wg.Wait()
+
+	var exported map[string]map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(m.Map.String()), &exported))
+	for _, endpoint := range []string{"submit-spam", "custom-check"} {
+		latency := exported["latency_seconds"][endpoint].(map[string]interface{})
+		assert.Equal(t, float64(50), latency["count"], endpoint)
+	}
+}
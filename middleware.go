@@ -0,0 +1,139 @@
+package akismet
+
+import (
+	"context"
+	"net/http"
+)
+
+// GuardPolicy is an action taken by SpamGuard for spam
+type GuardPolicy int
+
+// Possible guard policies
+const (
+	// Reject responds with GuardConfig.RejectStatus
+	Reject GuardPolicy = iota
+	// Annotate passes request to next handler, use CheckResultFromContext to read verdict
+	Annotate
+	// Redirect redirects to GuardConfig.RedirectURL
+	Redirect
+)
+
+// FormMapping contains names of form fields mapped to Options fields, empty name means field is not sent
+type FormMapping struct {
+	Content     string
+	Author      string
+	AuthorEmail string
+	AuthorURL   string
+	Permalink   string
+}
+
+// DefaultFormMapping maps "comment", "author", "email" and "url" form fields
+var DefaultFormMapping = FormMapping{
+	Content:     "comment",
+	Author:      "author",
+	AuthorEmail: "email",
+	AuthorURL:   "url",
+}
+
+// GuardConfig describes SpamGuard middleware
+type GuardConfig struct {
+	// Fields is form mapping, zero value means DefaultFormMapping
+	Fields      FormMapping
+	CommentType CommentType
+	Proxy       TrustedProxyConfig
+
+	Policy       GuardPolicy
+	RejectStatus int
+	RedirectURL  string
+
+	// Honeypot is a name of hidden form field, filled field means Discard verdict without calling Akismet
+	Honeypot string
+
+	// OnError is called when content can not be checked, by default request is passed to next handler
+	OnError func(w http.ResponseWriter, r *http.Request, next http.Handler, err error)
+}
+
+type checkResultKey struct{}
+
+// CheckResultFromContext returns CheckResult stored by SpamGuard
+func CheckResultFromContext(ctx context.Context) (*CheckResult, bool) {
+	result, ok := ctx.Value(checkResultKey{}).(*CheckResult)
+	return result, ok
+}
+
+// SpamGuard returns middleware which checks POST form submissions. Form fields are mapped to
+// Options according to cfg.Fields and request data is taken with OptionsFromRequest. Spam is
+// handled according to cfg.Policy, CheckResult is stored in request context for next handler.
+// Hold verdict of circuit breaker fallback is always passed to next handler.
+func (c *Client) SpamGuard(cfg GuardConfig) func(http.Handler) http.Handler {
+	if cfg.Fields == (FormMapping{}) {
+		cfg.Fields = DefaultFormMapping
+	}
+
+	if cfg.RejectStatus == 0 {
+		cfg.RejectStatus = http.StatusForbidden
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			result, err := c.guardCheck(r, cfg)
+			if err != nil {
+				if cfg.OnError != nil {
+					cfg.OnError(w, r, next, err)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Hold is not Akismet decision, next handler decides about it
+			r = r.WithContext(context.WithValue(r.Context(), checkResultKey{}, result))
+			if !result.IsSpam() || result.Verdict == Hold {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			switch cfg.Policy {
+			case Annotate:
+				next.ServeHTTP(w, r)
+			case Redirect:
+				http.Redirect(w, r, cfg.RedirectURL, http.StatusSeeOther)
+			default:
+				http.Error(w, http.StatusText(cfg.RejectStatus), cfg.RejectStatus)
+			}
+		})
+	}
+}
+
+func (c *Client) guardCheck(r *http.Request, cfg GuardConfig) (*CheckResult, error) {
+	o := OptionsFromRequest(r, cfg.Proxy)
+	o.CommentType = cfg.CommentType
+	o.Content = formValue(r, cfg.Fields.Content)
+	o.Author = formValue(r, cfg.Fields.Author)
+	o.AuthorEmail = formValue(r, cfg.Fields.AuthorEmail)
+	o.AuthorURL = formValue(r, cfg.Fields.AuthorURL)
+	o.Permalink = formValue(r, cfg.Fields.Permalink)
+
+	if cfg.Honeypot != "" {
+		o.HoneypotFieldName = cfg.Honeypot
+		o.HoneypotValue = formValue(r, cfg.Honeypot)
+		if o.HoneypotValue != "" {
+			return &CheckResult{Verdict: Discard}, nil
+		}
+	}
+
+	return c.CheckContext(r.Context(), o)
+}
+
+func formValue(r *http.Request, field string) string {
+	if field == "" {
+		return ""
+	}
+
+	return r.PostFormValue(field)
+}
@@ -0,0 +1,203 @@
+package akismet
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SebastianCzoch/akismet-go/akismettest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFormRequest(form url.Values) *http.Request {
+	r := httptest.NewRequest("POST", "http://example.com/comment", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("User-Agent", "TestUserAgent")
+	r.RemoteAddr = "192.0.2.1:4711"
+
+	return r
+}
+
+type guardTestHandler struct {
+	called bool
+	result *CheckResult
+}
+
+func (h *guardTestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.called = true
+	h.result, _ = CheckResultFromContext(r.Context())
+}
+
+func TestSpamGuardReject(t *testing.T) {
+	server := akismettest.NewServer("test_api_key")
+	defer server.Close()
+	client := NewClient("test_api_key", "http://example.com", WithBaseURL(server.URL))
+
+	next := &guardTestHandler{}
+	guard := client.SpamGuard(GuardConfig{CommentType: CommentTypeContactForm})(next)
+
+	w := httptest.NewRecorder()
+	guard.ServeHTTP(w, newFormRequest(url.Values{"author": {akismettest.SpamAuthor}, "comment": {"Buy now"}}))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.False(t, next.called)
+
+	requests := server.Requests()
+	if assert.Len(t, requests, 1) {
+		form := requests[0].Form
+		assert.Equal(t, "192.0.2.1", form.Get("user_ip"))
+		assert.Equal(t, "TestUserAgent", form.Get("user_agent"))
+		assert.Equal(t, "Buy now", form.Get("comment_content"))
+		assert.Equal(t, "contact-form", form.Get("comment_type"))
+	}
+
+	w = httptest.NewRecorder()
+	guard.ServeHTTP(w, newFormRequest(url.Values{"author": {"John"}, "comment": {"Nice post"}}))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, next.called)
+	if assert.NotNil(t, next.result) {
+		assert.Equal(t, Ham, next.result.Verdict)
+	}
+}
+
+func TestSpamGuardAnnotateAndMapping(t *testing.T) {
+	server := akismettest.NewServer("test_api_key")
+	defer server.Close()
+	client := NewClient("test_api_key", "http://example.com", WithBaseURL(server.URL))
+
+	next := &guardTestHandler{}
+	guard := client.SpamGuard(GuardConfig{
+		Policy: Annotate,
+		Fields: FormMapping{Content: "message", AuthorEmail: "mail"},
+	})(next)
+
+	w := httptest.NewRecorder()
+	guard.ServeHTTP(w, newFormRequest(url.Values{"mail": {akismettest.SpamAuthorEmail}, "message": {"Hi"}}))
+	assert.True(t, next.called)
+	if assert.NotNil(t, next.result) {
+		assert.Equal(t, Spam, next.result.Verdict)
+	}
+	assert.Equal(t, "Hi", server.Requests()[0].Form.Get("comment_content"))
+}
+
+func TestSpamGuardRedirect(t *testing.T) {
+	server := akismettest.NewServer("test_api_key")
+	defer server.Close()
+	client := NewClient("test_api_key", "http://example.com", WithBaseURL(server.URL))
+	server.QueueVerdicts(akismettest.Spam)
+
+	next := &guardTestHandler{}
+	guard := client.SpamGuard(GuardConfig{Policy: Redirect, RedirectURL: "/moderation"})(next)
+
+	w := httptest.NewRecorder()
+	guard.ServeHTTP(w, newFormRequest(url.Values{"comment": {"Hi"}}))
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	assert.Equal(t, "/moderation", w.Header().Get("Location"))
+	assert.False(t, next.called)
+}
+
+func TestSpamGuardHoneypot(t *testing.T) {
+	server := akismettest.NewServer("test_api_key")
+	defer server.Close()
+	client := NewClient("test_api_key", "http://example.com", WithBaseURL(server.URL))
+
+	next := &guardTestHandler{}
+	guard := client.SpamGuard(GuardConfig{Policy: Annotate, Honeypot: "website2"})(next)
+
+	guard.ServeHTTP(httptest.NewRecorder(), newFormRequest(url.Values{"comment": {"Hi"}, "website2": {"http://spam.example"}}))
+	assert.Equal(t, Discard, next.result.Verdict)
+	assert.Empty(t, server.Requests())
+
+	guard.ServeHTTP(httptest.NewRecorder(), newFormRequest(url.Values{"comment": {"Hi"}}))
+	assert.Equal(t, Ham, next.result.Verdict)
+	if assert.Len(t, server.Requests(), 1) {
+		assert.Equal(t, "website2", server.Requests()[0].Form.Get("honeypot_field_name"))
+	}
+}
+
+func TestSpamGuardSkipsGetAndHandlesErrors(t *testing.T) {
+	server := akismettest.NewServer("test_api_key")
+	defer server.Close()
+	client := NewClient("wrong_key", "http://example.com", WithBaseURL(server.URL))
+
+	next := &guardTestHandler{}
+	guard := client.SpamGuard(GuardConfig{})(next)
+	guard.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "http://example.com/comment", nil))
+	assert.True(t, next.called)
+	assert.Empty(t, server.Requests())
+
+	next = &guardTestHandler{}
+	var guardErr error
+	guard = client.SpamGuard(GuardConfig{OnError: func(w http.ResponseWriter, r *http.Request, next http.Handler, err error) {
+		guardErr = err
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}})(next)
+	w := httptest.NewRecorder()
+	guard.ServeHTTP(w, newFormRequest(url.Values{"comment": {"Hi"}}))
+	assert.True(t, errors.Is(guardErr, ErrInvalidRequest))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.False(t, next.called)
+}
+
+func TestSpamGuardPassesHoldToNextHandler(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(503)
+	}))
+	defer server.Close()
+	client := NewClient("test_api_key", "http://example.com", WithBaseURL(server.URL), WithCircuitBreaker(CircuitBreakerConfig{
+		Threshold: 1,
+		Cooldown:  time.Hour,
+		Fallback:  HoldForModeration,
+	}))
+
+	next := &guardTestHandler{}
+	guard := client.SpamGuard(GuardConfig{OnError: func(w http.ResponseWriter, r *http.Request, next http.Handler, err error) {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	}})(next)
+
+	w := httptest.NewRecorder()
+	guard.ServeHTTP(w, newFormRequest(url.Values{"author": {"John"}, "comment": {"Nice post"}}))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	w = httptest.NewRecorder()
+	guard.ServeHTTP(w, newFormRequest(url.Values{"author": {"John"}, "comment": {"Nice post"}}))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, next.called)
+	if assert.NotNil(t, next.result) {
+		assert.Equal(t, Hold, next.result.Verdict)
+		assert.True(t, next.result.Fallback)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestSpamGuardRejectsFailClosedFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(503)
+	}))
+	defer server.Close()
+	client := NewClient("test_api_key", "http://example.com", WithBaseURL(server.URL), WithCircuitBreaker(CircuitBreakerConfig{
+		Threshold: 1,
+		Cooldown:  time.Hour,
+		Fallback:  FailClosed,
+	}))
+
+	next := &guardTestHandler{}
+	guard := client.SpamGuard(GuardConfig{OnError: func(w http.ResponseWriter, r *http.Request, next http.Handler, err error) {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	}})(next)
+
+	w := httptest.NewRecorder()
+	guard.ServeHTTP(w, newFormRequest(url.Values{"author": {"John"}, "comment": {"Nice post"}}))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	w = httptest.NewRecorder()
+	guard.ServeHTTP(w, newFormRequest(url.Values{"author": {"John"}, "comment": {"Nice post"}}))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.False(t, next.called)
+}
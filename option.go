@@ -0,0 +1,44 @@
+package akismet
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option is a function which configures Client, it is passed to NewClient
+type Option func(*Client)
+
+// WithHTTPClient sets http.Client used for all requests to Akismet API,
+// nil is ignored and default client is used
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithBaseURL sets address of Akismet API (or Akismet compatible service),
+// e.g. "http://127.0.0.1:8080". API version and endpoint path are appended to it.
+// With custom base URL API key is sent as api_key parameter instead of subdomain.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.registry.BaseURL = baseURL
+		c.registry.Auth = KeyAsParameter
+	}
+}
+
+// WithUserAgent sets name and version of your application, e.g. "MyBlog/1.2".
+// Akismet-go version is appended to it, as Akismet asks for.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent + " | " + defaultUserAgent
+	}
+}
+
+// WithTimeout sets time limit for single request to Akismet API
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
@@ -0,0 +1,67 @@
+package akismet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithHTTPClient(t *testing.T) {
+	httpClient := &http.Client{}
+	client := NewClient("test_api_key", "test_site", WithHTTPClient(httpClient))
+	assert.True(t, httpClient == client.httpClient)
+}
+
+func TestWithHTTPClientNil(t *testing.T) {
+	client := NewClient("test_api_key", "test_site", WithHTTPClient(nil), WithTimeout(time.Second))
+	if assert.NotNil(t, client.httpClient) {
+		assert.Equal(t, time.Second, client.httpClient.Timeout)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	httpClient := &http.Client{}
+	client := NewClient("test_api_key", "test_site", WithHTTPClient(httpClient), WithTimeout(time.Second))
+	assert.Equal(t, time.Second, client.httpClient.Timeout)
+	assert.Equal(t, time.Duration(0), httpClient.Timeout)
+}
+
+func TestWithBaseURLEndpointURL(t *testing.T) {
+	client := NewClient("test_api_key", "test_site", WithBaseURL("http://127.0.0.1:8080/akismet/"))
+	address, err := client.getEndpointURL("withKey")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://127.0.0.1:8080/akismet/1.1/with-key", address)
+
+	client = NewClient("test_api_key", "test_site", WithBaseURL("://wrong"))
+	_, err = client.getEndpointURL("withKey")
+	assert.Error(t, err)
+}
+
+func TestWithBaseURLAndUserAgent(t *testing.T) {
+	var received *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		received = r
+		w.Write([]byte("true"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithUserAgent("MyBlog/1.2"))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	res, err := client.IsSpam(options)
+	assert.Nil(t, err)
+	assert.True(t, res)
+	if assert.NotNil(t, received) {
+		assert.Equal(t, "/1.1/comment-check", received.URL.Path)
+		assert.Equal(t, "test_api_key", received.PostForm.Get("api_key"))
+		assert.Equal(t, "MyBlog/1.2 | Akismet-go/"+LibraryVersion, received.Header.Get("User-Agent"))
+	}
+}
+
+func TestDefaultUserAgent(t *testing.T) {
+	client := NewClient("test_api_key", "test_site")
+	assert.Equal(t, "Akismet-go/"+LibraryVersion, client.userAgent)
+}
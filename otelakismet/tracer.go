@@ -0,0 +1,65 @@
+// Package otelakismet is OpenTelemetry adapter for akismet.Tracer
+package otelakismet
+
+import (
+	"context"
+
+	"github.com/SebastianCzoch/akismet-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span attribute keys
+const (
+	EndpointKey   = attribute.Key("akismet.endpoint")
+	VerdictKey    = attribute.Key("akismet.verdict")
+	RetriesKey    = attribute.Key("akismet.retries")
+	CacheHitKey   = attribute.Key("akismet.cache_hit")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+)
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer returns akismet.Tracer which starts client spans with t, use it with akismet.WithTracer
+func NewTracer(t trace.Tracer) akismet.Tracer {
+	return &tracer{tracer: t}
+}
+
+func (t *tracer) Start(ctx context.Context, endpoint string) (context.Context, akismet.Span) {
+	ctx, s := t.tracer.Start(ctx, "akismet "+endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(EndpointKey.String(endpoint)),
+	)
+
+	return ctx, &span{span: s}
+}
+
+type span struct {
+	span trace.Span
+}
+
+func (s *span) End(info akismet.SpanInfo) {
+	attrs := []attribute.KeyValue{
+		RetriesKey.Int(info.Retries),
+		CacheHitKey.Bool(info.CacheHit),
+	}
+
+	if info.Verdict != "" {
+		attrs = append(attrs, VerdictKey.String(info.Verdict))
+	}
+
+	if info.StatusCode != 0 {
+		attrs = append(attrs, StatusCodeKey.Int(info.StatusCode))
+	}
+
+	s.span.SetAttributes(attrs...)
+	if info.Err != nil {
+		s.span.RecordError(info.Err)
+		s.span.SetStatus(codes.Error, info.Err.Error())
+	}
+
+	s.span.End()
+}
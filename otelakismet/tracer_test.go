@@ -0,0 +1,92 @@
+package otelakismet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SebastianCzoch/akismet-go"
+	"github.com/SebastianCzoch/akismet-go/akismettest"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+)
+
+type fakeTracer struct {
+	embedded.Tracer
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	s := &fakeSpan{name: name, kind: cfg.SpanKind(), attrs: cfg.Attributes()}
+	t.spans = append(t.spans, s)
+
+	return ctx, s
+}
+
+type fakeSpan struct {
+	trace.Span
+	name   string
+	kind   trace.SpanKind
+	attrs  []attribute.KeyValue
+	status codes.Code
+	errs   []error
+	ended  bool
+}
+
+func (s *fakeSpan) SetAttributes(kv ...attribute.KeyValue)        { s.attrs = append(s.attrs, kv...) }
+func (s *fakeSpan) RecordError(err error, _ ...trace.EventOption) { s.errs = append(s.errs, err) }
+func (s *fakeSpan) SetStatus(code codes.Code, _ string)           { s.status = code }
+func (s *fakeSpan) End(...trace.SpanEndOption)                    { s.ended = true }
+
+func (s *fakeSpan) attr(key attribute.Key) attribute.Value {
+	for _, kv := range s.attrs {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+
+	return attribute.Value{}
+}
+
+func TestTracer(t *testing.T) {
+	server := akismettest.NewServer("test_api_key")
+	defer server.Close()
+
+	ft := &fakeTracer{}
+	client := akismet.NewClient("test_api_key", "http://example.com", akismet.WithBaseURL(server.URL), akismet.WithTracer(NewTracer(ft)))
+	options := akismet.Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent", Author: akismettest.SpamAuthor}
+	_, err := client.Check(options)
+	assert.Nil(t, err)
+
+	if assert.Len(t, ft.spans, 1) {
+		s := ft.spans[0]
+		assert.True(t, s.ended)
+		assert.Equal(t, "akismet comment-check", s.name)
+		assert.Equal(t, trace.SpanKindClient, s.kind)
+		assert.Equal(t, "comment-check", s.attr(EndpointKey).AsString())
+		assert.Equal(t, "spam", s.attr(VerdictKey).AsString())
+		assert.Equal(t, int64(200), s.attr(StatusCodeKey).AsInt64())
+		assert.Equal(t, int64(0), s.attr(RetriesKey).AsInt64())
+		assert.False(t, s.attr(CacheHitKey).AsBool())
+		assert.Equal(t, codes.Unset, s.status)
+	}
+}
+
+func TestTracerError(t *testing.T) {
+	server := akismettest.NewServer("test_api_key")
+	defer server.Close()
+
+	ft := &fakeTracer{}
+	client := akismet.NewClient("wrong_key", "http://example.com", akismet.WithBaseURL(server.URL), akismet.WithTracer(NewTracer(ft)))
+	assert.Error(t, client.VerifyKey(context.Background()))
+
+	if assert.Len(t, ft.spans, 1) {
+		s := ft.spans[0]
+		assert.Equal(t, "akismet verify-key", s.name)
+		assert.Len(t, s.errs, 1)
+		assert.Equal(t, codes.Error, s.status)
+	}
+}
@@ -0,0 +1,159 @@
+package akismet
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit allows Limit requests per Per duration with bursts up to Burst requests (default 1),
+// e.g. RateLimit{Limit: 10, Per: time.Second, Burst: 10}. Zero value means no limit.
+// Quota over longer period needs Burst equal to Limit, e.g. 100k requests per rolling month
+// is RateLimit{Limit: 100000, Per: 30 * 24 * time.Hour, Burst: 100000}.
+type RateLimit struct {
+	Limit int
+	Per   time.Duration
+	Burst int
+}
+
+// RateLimitConfig sets separate budgets for comment-check and submissions (submit-spam, submit-ham).
+// Every class can have many limits, e.g. per second and monthly quota, request needs budget in all of them.
+type RateLimitConfig struct {
+	Check  []RateLimit
+	Submit []RateLimit
+	// FailFast makes client return ErrRateLimited instead of waiting for budget
+	FailFast bool
+}
+
+// WithRateLimit sets client side rate limiter
+func WithRateLimit(cfg RateLimitConfig) Option {
+	return func(c *Client) {
+		c.checkLimiter = newRateLimiter(cfg.Check, cfg.FailFast)
+		c.submitLimiter = newRateLimiter(cfg.Submit, cfg.FailFast)
+	}
+}
+
+// rateLimiter takes token from every bucket
+type rateLimiter struct {
+	buckets  []*tokenBucket
+	failFast bool
+}
+
+func newRateLimiter(limits []RateLimit, failFast bool) *rateLimiter {
+	l := &rateLimiter{failFast: failFast}
+	for _, limit := range limits {
+		if b := newTokenBucket(limit); b != nil {
+			l.buckets = append(l.buckets, b)
+		}
+	}
+
+	if len(l.buckets) == 0 {
+		return nil
+	}
+
+	return l
+}
+
+// wait takes one token from every bucket, waiting for them or returning ErrRateLimited in
+// fail fast mode or when the tokens would not be available before ctx deadline
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	now := time.Now()
+	var delay time.Duration
+	for _, b := range l.buckets {
+		if d := b.reserve(now); d > delay {
+			delay = d
+		}
+	}
+
+	if delay == 0 {
+		return nil
+	}
+
+	deadline, ok := ctx.Deadline()
+	if l.failFast || (ok && now.Add(delay).After(deadline)) {
+		l.cancel()
+		return ErrRateLimited
+	}
+
+	if err := sleep(ctx, delay); err != nil {
+		l.cancel()
+		return err
+	}
+
+	return nil
+}
+
+func (l *rateLimiter) cancel() {
+	for _, b := range l.buckets {
+		b.cancel()
+	}
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per nanosecond
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(l RateLimit) *tokenBucket {
+	if l.Limit <= 0 || l.Per <= 0 {
+		return nil
+	}
+
+	burst := l.Burst
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &tokenBucket{
+		rate:   float64(l.Limit) / float64(l.Per),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes one token and returns time after which it is available
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.After(b.last) {
+		b.tokens += float64(now.Sub(b.last)) * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate)
+}
+
+// cancel returns reserved token
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	b.tokens++
+	b.mu.Unlock()
+}
+
+func (c *Client) limiter(endpointName string) *rateLimiter {
+	switch {
+	case endpointName == EndpointCommentCheck:
+		return c.checkLimiter
+	case isSubmission(endpointName):
+		return c.submitLimiter
+	}
+
+	return nil
+}
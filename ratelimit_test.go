@@ -0,0 +1,125 @@
+package akismet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCountingServer(body string) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(body))
+	}))
+
+	return server, &calls
+}
+
+func TestRateLimitFailFast(t *testing.T) {
+	server, calls := newCountingServer("false")
+	defer server.Close()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithRateLimit(RateLimitConfig{
+		Check:    []RateLimit{{Limit: 1, Per: time.Hour, Burst: 2}},
+		FailFast: true,
+	}))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	for i := 0; i < 2; i++ {
+		_, err := client.IsSpam(options)
+		assert.Nil(t, err)
+	}
+
+	_, err := client.IsSpam(options)
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestRateLimitSeparateBudgets(t *testing.T) {
+	server, calls := newCountingServer(SubmitResponseContentOK)
+	defer server.Close()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithRateLimit(RateLimitConfig{
+		Check:    []RateLimit{{Limit: 1, Per: time.Hour}},
+		FailFast: true,
+	}))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, client.SubmitHam(options))
+	}
+	assert.False(t, errors.Is(client.VerifyKey(context.Background()), ErrRateLimited))
+	assert.Equal(t, int32(4), atomic.LoadInt32(calls))
+}
+
+func TestRateLimitWaits(t *testing.T) {
+	server, calls := newCountingServer("false")
+	defer server.Close()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithRateLimit(RateLimitConfig{
+		Check: []RateLimit{{Limit: 1, Per: 30 * time.Millisecond}},
+	}))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.IsSpam(options)
+		assert.Nil(t, err)
+	}
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestRateLimitDeadline(t *testing.T) {
+	server, calls := newCountingServer("false")
+	defer server.Close()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithRateLimit(RateLimitConfig{
+		Check: []RateLimit{{Limit: 1, Per: time.Hour}},
+	}))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	_, err := client.IsSpam(options)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = client.IsSpamContext(ctx, options)
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestRateLimitMultipleLimits(t *testing.T) {
+	server, calls := newCountingServer("false")
+	defer server.Close()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithRateLimit(RateLimitConfig{
+		Check: []RateLimit{
+			{Limit: 2, Per: 20 * time.Millisecond, Burst: 2},
+			{Limit: 3, Per: 30 * 24 * time.Hour, Burst: 3},
+		},
+		FailFast: true,
+	}))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	for i := 0; i < 2; i++ {
+		_, err := client.IsSpam(options)
+		assert.Nil(t, err)
+	}
+
+	// per second budget is exhausted, monthly quota keeps its token
+	_, err := client.IsSpam(options)
+	assert.True(t, errors.Is(err, ErrRateLimited))
+
+	time.Sleep(25 * time.Millisecond)
+	_, err = client.IsSpam(options)
+	assert.Nil(t, err)
+
+	// monthly quota is exhausted
+	time.Sleep(25 * time.Millisecond)
+	_, err = client.IsSpam(options)
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
@@ -0,0 +1,90 @@
+package akismet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AuthStyle is a way API key is passed to endpoints which require it
+type AuthStyle int
+
+// Possible auth styles
+const (
+	// KeyInSubdomain sends key in API host built from Registry.HostTemplate, e.g. <key>.rest.akismet.com
+	KeyInSubdomain AuthStyle = iota
+	// KeyAsParameter sends key as api_key parameter
+	KeyAsParameter
+)
+
+// Registry describes API used by Client: its address, version, auth style and endpoints.
+// Use it to call newer API versions or Akismet compatible services.
+type Registry struct {
+	// BaseURL is an address of API without version, e.g. "https://rest.akismet.com"
+	BaseURL string
+	// HostTemplate is a host of endpoints requiring key with KeyInSubdomain auth, {key} is replaced
+	// by API key, e.g. "{key}.rest.akismet.com". Empty template means "{key}." followed by BaseURL host.
+	HostTemplate string
+	Version      string
+	Auth         AuthStyle
+	Endpoints    map[string]Endpoint
+}
+
+// DefaultRegistry returns registry of Akismet API
+func DefaultRegistry() Registry {
+	endpoints := make(map[string]Endpoint, len(apiEndpoints))
+	for name, endpoint := range apiEndpoints {
+		endpoints[name] = endpoint
+	}
+
+	return Registry{
+		BaseURL:      fmt.Sprintf("%s://%s", APIProtocol, APIAddress),
+		HostTemplate: "{key}." + APIAddress,
+		Version:      APIVersion,
+		Auth:         KeyInSubdomain,
+		Endpoints:    endpoints,
+	}
+}
+
+// TypePadAntiSpamRegistry returns registry of TypePad AntiSpam, Akismet compatible service
+func TypePadAntiSpamRegistry() Registry {
+	r := DefaultRegistry()
+	r.BaseURL = "https://api.antispam.typepad.com"
+	r.HostTemplate = "{key}.api.antispam.typepad.com"
+	delete(r.Endpoints, EndpointUsageLimit)
+	delete(r.Endpoints, EndpointKeySites)
+
+	return r
+}
+
+// WithRegistry sets API registry, it replaces registry set by WithBaseURL and WithAPIVersion
+func WithRegistry(r Registry) Option {
+	return func(c *Client) {
+		c.registry = r
+	}
+}
+
+// WithAPIVersion sets API version used by endpoints without own version
+func WithAPIVersion(version string) Option {
+	return func(c *Client) {
+		c.registry.Version = version
+	}
+}
+
+func (r Registry) endpoint(name string) (*Endpoint, error) {
+	endpoint, ok := r.Endpoints[name]
+	if !ok {
+		return nil, fmt.Errorf("endpoint %s not found", name)
+	}
+
+	return &endpoint, nil
+}
+
+// keyHost returns host with API key for KeyInSubdomain auth
+func (r Registry) keyHost(apiKey, baseHost string) string {
+	template := r.HostTemplate
+	if template == "" {
+		template = "{key}." + baseHost
+	}
+
+	return strings.Replace(template, "{key}", apiKey, -1)
+}
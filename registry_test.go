@@ -0,0 +1,126 @@
+package akismet
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultRegistry(t *testing.T) {
+	r := DefaultRegistry()
+	assert.Equal(t, "https://rest.akismet.com", r.BaseURL)
+	assert.Equal(t, "{key}.rest.akismet.com", r.HostTemplate)
+	assert.Equal(t, APIVersion, r.Version)
+	assert.Equal(t, KeyInSubdomain, r.Auth)
+	assert.Equal(t, Endpoint{"comment-check", "POST", true, ""}, r.Endpoints[EndpointCommentCheck])
+
+	r.Endpoints[EndpointCommentCheck] = Endpoint{"changed", "GET", false, ""}
+	assert.Equal(t, "comment-check", DefaultRegistry().Endpoints[EndpointCommentCheck].Path)
+}
+
+func TestWithAPIVersion(t *testing.T) {
+	client := NewClient("test_api_key", "test_site", WithAPIVersion("1.2"))
+	address, err := client.getEndpointURL(EndpointCommentCheck)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://test_api_key.rest.akismet.com/1.2/comment-check", address)
+
+	address, err = client.getEndpointURL(EndpointUsageLimit)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://rest.akismet.com/1.2/usage-limit", address)
+}
+
+func TestTypePadAntiSpamRegistry(t *testing.T) {
+	client := NewClient("test_api_key", "test_site", WithRegistry(TypePadAntiSpamRegistry()))
+	address, err := client.getEndpointURL(EndpointSubmitHam)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://test_api_key.api.antispam.typepad.com/1.1/submit-ham", address)
+
+	_, err = client.getEndpointURL(EndpointKeySites)
+	assert.Error(t, err)
+}
+
+func TestCustomRegistry(t *testing.T) {
+	var received *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		received = r
+		w.Write([]byte("true"))
+	}))
+	defer server.Close()
+
+	registry := Registry{
+		BaseURL: server.URL + "/antispam",
+		Version: "2.0",
+		Auth:    KeyAsParameter,
+		Endpoints: map[string]Endpoint{
+			EndpointCommentCheck: Endpoint{"check", "POST", true, ""},
+		},
+	}
+	client := NewClient("test_api_key", "test_site", WithRegistry(registry))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	res, err := client.IsSpam(options)
+	assert.Nil(t, err)
+	assert.True(t, res)
+	if assert.NotNil(t, received) {
+		assert.Equal(t, "/antispam/2.0/check", received.URL.Path)
+		assert.Equal(t, "test_api_key", received.PostForm.Get("api_key"))
+	}
+}
+
+func TestRegistryHostTemplate(t *testing.T) {
+	registry := DefaultRegistry()
+	registry.BaseURL = "https://api.example.com/antispam"
+	registry.HostTemplate = "antispam-{key}.keys.example.com"
+	client := NewClient("test_api_key", "test_site", WithRegistry(registry))
+
+	address, err := client.getEndpointURL(EndpointCommentCheck)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://antispam-test_api_key.keys.example.com/antispam/1.1/comment-check", address)
+
+	address, err = client.getEndpointURL(EndpointVerifyKey)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://api.example.com/antispam/1.1/verify-key", address)
+
+	registry.HostTemplate = ""
+	client = NewClient("test_api_key", "test_site", WithRegistry(registry))
+	address, err = client.getEndpointURL(EndpointCommentCheck)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://test_api_key.api.example.com/antispam/1.1/comment-check", address)
+}
+
+func TestRegistryCustomPathsKeepRetryAndRateLimitClasses(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Write([]byte("false"))
+	}))
+	defer server.Close()
+
+	registry := DefaultRegistry()
+	registry.BaseURL = server.URL
+	registry.Auth = KeyAsParameter
+	registry.Endpoints[EndpointSubmitSpam] = Endpoint{"report-spam", "POST", true, ""}
+	registry.Endpoints[EndpointCommentCheck] = Endpoint{"check", "POST", true, ""}
+	client := NewClient("test_api_key", "test_site", WithRegistry(registry),
+		WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}),
+		WithRateLimit(RateLimitConfig{Check: []RateLimit{{Limit: 1, Per: time.Hour}}, FailFast: true}))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+
+	// submission is not retried
+	assert.True(t, errors.Is(client.SubmitSpam(options), ErrUnexpectedStatus))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	_, err := client.IsSpam(options)
+	assert.Nil(t, err)
+	_, err = client.IsSpam(options)
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,143 @@
+package akismet
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// TrustedProxyConfig describes proxies in front of your application. Proxy headers
+// (Forwarded, X-Forwarded-For) are used only when request comes from trusted proxy.
+type TrustedProxyConfig struct {
+	Proxies []*net.IPNet
+
+	// ServerEnvHeaders are names of request headers sent to Akismet as server
+	// environment variables, e.g. "Accept" is sent as HTTP_ACCEPT
+	ServerEnvHeaders []string
+}
+
+// NewTrustedProxyConfig creates TrustedProxyConfig from list of IP addresses or CIDR ranges
+func NewTrustedProxyConfig(proxies ...string) (TrustedProxyConfig, error) {
+	cfg := TrustedProxyConfig{}
+	for _, p := range proxies {
+		if !strings.Contains(p, "/") {
+			ip := net.ParseIP(p)
+			if ip == nil {
+				return cfg, &net.ParseError{Type: "IP address", Text: p}
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			cfg.Proxies = append(cfg.Proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(p)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Proxies = append(cfg.Proxies, network)
+	}
+
+	return cfg, nil
+}
+
+// OptionsFromRequest creates Options with user IP, user agent, referrer and
+// server environment (Accept-Language and ServerEnvHeaders) taken from r
+func OptionsFromRequest(r *http.Request, cfg TrustedProxyConfig) Options {
+	o := Options{
+		UserAgent: r.UserAgent(),
+		Referrer:  r.Referer(),
+		ServerEnv: map[string]string{},
+	}
+
+	if ip := cfg.clientIP(r); ip != nil {
+		o.UserIP = ip.String()
+	}
+
+	headers := append([]string{"Accept-Language"}, cfg.ServerEnvHeaders...)
+	for _, name := range headers {
+		if value := r.Header.Get(name); value != "" {
+			o.ServerEnv[serverEnvName(name)] = value
+		}
+	}
+
+	return o
+}
+
+func (cfg TrustedProxyConfig) clientIP(r *http.Request) net.IP {
+	ip := parseHostIP(r.RemoteAddr)
+	if ip == nil || !cfg.isTrusted(ip) {
+		return ip
+	}
+
+	chain := forwardedFor(r.Header)
+	if len(chain) == 0 {
+		chain = xForwardedFor(r.Header)
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		hop := parseHostIP(chain[i])
+		if hop == nil {
+			break
+		}
+
+		ip = hop
+		if !cfg.isTrusted(hop) {
+			break
+		}
+	}
+
+	return ip
+}
+
+func (cfg TrustedProxyConfig) isTrusted(ip net.IP) bool {
+	for _, network := range cfg.Proxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func forwardedFor(h http.Header) []string {
+	var chain []string
+	for _, value := range h.Values("Forwarded") {
+		for _, element := range strings.Split(value, ",") {
+			for _, pair := range strings.Split(element, ";") {
+				pair = strings.TrimSpace(pair)
+				if len(pair) > 4 && strings.EqualFold(pair[:4], "for=") {
+					chain = append(chain, strings.Trim(pair[4:], `"`))
+				}
+			}
+		}
+	}
+
+	return chain
+}
+
+func xForwardedFor(h http.Header) []string {
+	var chain []string
+	for _, value := range h.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(value, ",") {
+			chain = append(chain, strings.TrimSpace(hop))
+		}
+	}
+
+	return chain
+}
+
+// parseHostIP parses IP from "ip", "ip:port", "[ipv6]" or "[ipv6]:port"
+func parseHostIP(address string) net.IP {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+
+	return net.ParseIP(strings.Trim(address, "[]"))
+}
+
+func serverEnvName(header string) string {
+	return "HTTP_" + strings.ToUpper(strings.Replace(header, "-", "_", -1))
+}
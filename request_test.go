@@ -0,0 +1,90 @@
+package akismet
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRequest(remoteAddr string, header map[string]string) *http.Request {
+	r, _ := http.NewRequest("POST", "http://example.com/comment", nil)
+	r.RemoteAddr = remoteAddr
+	for k, v := range header {
+		r.Header.Set(k, v)
+	}
+
+	return r
+}
+
+func TestNewTrustedProxyConfig(t *testing.T) {
+	cfg, err := NewTrustedProxyConfig("10.0.0.0/8", "192.168.1.1", "::1")
+	assert.Nil(t, err)
+	assert.Len(t, cfg.Proxies, 3)
+
+	_, err = NewTrustedProxyConfig("not-an-ip")
+	assert.Error(t, err)
+
+	_, err = NewTrustedProxyConfig("10.0.0.0/99")
+	assert.Error(t, err)
+}
+
+func TestOptionsFromRequest(t *testing.T) {
+	r := newTestRequest("203.0.113.7:4711", map[string]string{
+		"User-Agent":      "TestUserAgent",
+		"Referer":         "http://example.com/post",
+		"Accept-Language": "en-US,en;q=0.5",
+		"Accept":          "text/html",
+		"X-Forwarded-For": "198.51.100.1",
+	})
+
+	o := OptionsFromRequest(r, TrustedProxyConfig{ServerEnvHeaders: []string{"Accept", "Accept-Encoding"}})
+	assert.Equal(t, "203.0.113.7", o.UserIP)
+	assert.Equal(t, "TestUserAgent", o.UserAgent)
+	assert.Equal(t, "http://example.com/post", o.Referrer)
+	assert.Equal(t, map[string]string{
+		"HTTP_ACCEPT_LANGUAGE": "en-US,en;q=0.5",
+		"HTTP_ACCEPT":          "text/html",
+	}, o.ServerEnv)
+}
+
+func TestOptionsFromRequestXForwardedFor(t *testing.T) {
+	cfg, _ := NewTrustedProxyConfig("10.0.0.0/8")
+	r := newTestRequest("10.0.0.1:4711", map[string]string{
+		"X-Forwarded-For": "192.0.2.1, 198.51.100.1, 10.0.0.2",
+	})
+
+	o := OptionsFromRequest(r, cfg)
+	assert.Equal(t, "198.51.100.1", o.UserIP)
+}
+
+func TestOptionsFromRequestAllTrusted(t *testing.T) {
+	cfg, _ := NewTrustedProxyConfig("10.0.0.0/8")
+	r := newTestRequest("10.0.0.1:4711", map[string]string{
+		"X-Forwarded-For": "10.0.0.3, 10.0.0.2",
+	})
+
+	o := OptionsFromRequest(r, cfg)
+	assert.Equal(t, "10.0.0.3", o.UserIP)
+}
+
+func TestOptionsFromRequestForwarded(t *testing.T) {
+	cfg, _ := NewTrustedProxyConfig("::1")
+	r := newTestRequest("[::1]:4711", map[string]string{
+		"Forwarded":       `for=192.0.2.43, for="[2001:db8:cafe::17]:4711";proto=https`,
+		"X-Forwarded-For": "198.51.100.1",
+	})
+
+	o := OptionsFromRequest(r, cfg)
+	assert.Equal(t, "2001:db8:cafe::17", o.UserIP)
+}
+
+func TestOptionsFromRequestInvalidHop(t *testing.T) {
+	cfg, _ := NewTrustedProxyConfig("10.0.0.0/8")
+	r := newTestRequest("10.0.0.1:4711", map[string]string{
+		"Forwarded": `for=198.51.100.1, for=unknown`,
+	})
+
+	o := OptionsFromRequest(r, cfg)
+	assert.Equal(t, "10.0.0.1", o.UserIP)
+}
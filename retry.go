@@ -0,0 +1,130 @@
+package akismet
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how transient failures (transport errors, HTTP 429 and 5xx)
+// are retried. Zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is a maximum number of attempts including the first one
+	MaxAttempts int
+	// BaseDelay is a delay before the second attempt, it doubles with every next attempt
+	BaseDelay time.Duration
+	// MaxDelay limits delay between attempts, zero means one minute. Request is not
+	// retried when Retry-After header asks for longer delay.
+	MaxDelay time.Duration
+	// RetrySubmissions allows retrying submit-spam and submit-ham. Akismet may
+	// record the same submission twice when a response is lost, so it is disabled by default.
+	RetrySubmissions bool
+	// OnRetry is called before every retry
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes retry which is going to happen
+type RetryEvent struct {
+	Endpoint string
+	Attempt  int
+	Delay    time.Duration
+	Err      error
+}
+
+// DefaultRetryPolicy returns policy with 3 attempts and delay starting from 200ms
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+	}
+}
+
+// defaultMaxDelay is used when RetryPolicy.MaxDelay is zero
+const defaultMaxDelay = time.Minute
+
+// WithRetry sets retry policy for transient failures
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// delay returns time to wait before next attempt, ok is false when request should not be retried
+func (p RetryPolicy) delay(ctx context.Context, endpointName string, attempt int, r *apiResponse, err error) (delay time.Duration, ok bool) {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return 0, false
+	}
+
+	if isSubmission(endpointName) && !p.RetrySubmissions {
+		return 0, false
+	}
+
+	if r != nil && r.statusCode != http.StatusTooManyRequests && r.statusCode < 500 {
+		return 0, false
+	}
+
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxDelay
+	}
+
+	if r != nil {
+		if d, ok := retryAfter(r.header.Get("Retry-After")); ok {
+			return d, d <= maxDelay
+		}
+	}
+
+	// BaseDelay << shift is checked against maxDelay before shifting, so it can not overflow
+	delay = maxDelay
+	if shift := uint(attempt - 1); shift < 63 && p.BaseDelay <= maxDelay>>shift {
+		delay = p.BaseDelay << shift
+	}
+
+	if delay > 1 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+
+	return delay, true
+}
+
+// isSubmission reports whether endpoint is submit-spam or submit-ham, name is registry key,
+// so endpoints with changed path are classified the same way
+func isSubmission(endpointName string) bool {
+	return endpointName == EndpointSubmitSpam || endpointName == EndpointSubmitHam
+}
+
+// retryAfter parses Retry-After header in seconds or HTTP date format
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,161 @@
+package akismet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newRetryServer(statuses ...int) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+		if n <= len(statuses) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		if r.URL.Path == "/1.1/comment-check" {
+			w.Write([]byte("true"))
+			return
+		}
+		w.Write([]byte(SubmitResponseContentOK))
+	}))
+
+	return server, &calls
+}
+
+func TestRetryCommentCheck(t *testing.T) {
+	server, calls := newRetryServer(503, 429)
+	defer server.Close()
+
+	var events []RetryEvent
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, OnRetry: func(e RetryEvent) { events = append(events, e) }}
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithRetry(policy))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	res, err := client.IsSpam(options)
+	assert.Nil(t, err)
+	assert.True(t, res)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, "comment-check", events[0].Endpoint)
+		assert.Equal(t, 2, events[0].Attempt)
+		assert.Equal(t, 3, events[1].Attempt)
+		assert.True(t, errors.Is(events[0].Err, ErrUnexpectedStatus))
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	server, calls := newRetryServer(500, 500, 500)
+	defer server.Close()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithRetry(RetryPolicy{MaxAttempts: 2}))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	_, err := client.IsSpam(options)
+	assert.True(t, errors.Is(err, ErrUnexpectedStatus))
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestRetryNotOnClientError(t *testing.T) {
+	server, calls := newRetryServer(400)
+	defer server.Close()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithRetry(RetryPolicy{MaxAttempts: 3}))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	_, err := client.IsSpam(options)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestRetrySubmissions(t *testing.T) {
+	server, calls := newRetryServer(503)
+	defer server.Close()
+
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithRetry(RetryPolicy{MaxAttempts: 3}))
+	assert.Error(t, client.SubmitSpam(options))
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+
+	atomic.StoreInt32(calls, 0)
+	client = NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithRetry(RetryPolicy{MaxAttempts: 3, RetrySubmissions: true}))
+	assert.Nil(t, client.SubmitSpam(options))
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(503)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithRetry(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour}))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	_, err := client.IsSpamContext(ctx, options)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt := 1; attempt < 8; attempt++ {
+		d, ok := policy.delay(context.Background(), EndpointCommentCheck, attempt, nil, errors.New("connection reset"))
+		assert.True(t, ok)
+		max := policy.BaseDelay << uint(attempt-1)
+		if max > policy.MaxDelay {
+			max = policy.MaxDelay
+		}
+		assert.True(t, d >= max/2 && d <= max, "delay %s out of range for attempt %d", d, attempt)
+	}
+
+	r := &apiResponse{statusCode: 503, header: http.Header{"Retry-After": []string{"1"}}}
+	d, ok := policy.delay(context.Background(), EndpointCommentCheck, 1, r, ErrUnexpectedStatus)
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, d)
+
+	r = &apiResponse{statusCode: 503, header: http.Header{"Retry-After": []string{"7"}}}
+	_, ok = policy.delay(context.Background(), EndpointCommentCheck, 1, r, ErrUnexpectedStatus)
+	assert.False(t, ok)
+
+	r = &apiResponse{statusCode: 429, header: http.Header{"Retry-After": []string{"3600"}}}
+	_, ok = RetryPolicy{MaxAttempts: 3}.delay(context.Background(), EndpointCommentCheck, 1, r, ErrUnexpectedStatus)
+	assert.False(t, ok)
+
+	_, ok = policy.delay(context.Background(), EndpointCommentCheck, 10, nil, errors.New("connection reset"))
+	assert.False(t, ok)
+}
+
+func TestRetryDelayOverflow(t *testing.T) {
+	for _, policy := range []RetryPolicy{
+		{MaxAttempts: 100, BaseDelay: time.Hour},
+		{MaxAttempts: 100, BaseDelay: 100 * time.Millisecond},
+		{MaxAttempts: 100, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second},
+	} {
+		max := policy.MaxDelay
+		if max == 0 {
+			max = defaultMaxDelay
+		}
+
+		for _, attempt := range []int{30, 40, 64, 65, 99} {
+			d, ok := policy.delay(context.Background(), EndpointCommentCheck, attempt, nil, errors.New("connection reset"))
+			assert.True(t, ok)
+			assert.True(t, d >= max/2 && d <= max, "delay %s out of range for attempt %d", d, attempt)
+		}
+	}
+}
+
+func TestRetryAfterDate(t *testing.T) {
+	d, ok := retryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.True(t, d > 50*time.Second && d <= time.Minute)
+
+	_, ok = retryAfter("soon")
+	assert.False(t, ok)
+}
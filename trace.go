@@ -0,0 +1,88 @@
+package akismet
+
+import (
+	"context"
+)
+
+// Tracer starts spans around Akismet API calls. Implement it to connect Client
+// with your tracing system, package otelakismet contains OpenTelemetry adapter.
+type Tracer interface {
+	Start(ctx context.Context, endpoint string) (context.Context, Span)
+}
+
+// Span is a single traced API call
+type Span interface {
+	End(info SpanInfo)
+}
+
+// SpanInfo contains attributes of finished span. Verdict is empty for endpoints other
+// than comment-check and StatusCode is 0 when no response was received.
+type SpanInfo struct {
+	Endpoint   string
+	Verdict    string
+	StatusCode int
+	Retries    int
+	CacheHit   bool
+	Err        error
+}
+
+// WithTracer sets tracer
+func WithTracer(t Tracer) Option {
+	return func(c *Client) {
+		c.tracer = t
+	}
+}
+
+type spanKey struct{}
+
+type spanRecorder struct {
+	span Span
+	info SpanInfo
+}
+
+func (c *Client) startSpan(ctx context.Context, endpoint string) (context.Context, *spanRecorder) {
+	if c.tracer == nil {
+		return ctx, nil
+	}
+
+	ctx, span := c.tracer.Start(ctx, endpoint)
+	rec := &spanRecorder{span: span, info: SpanInfo{Endpoint: endpoint}}
+
+	return context.WithValue(ctx, spanKey{}, rec), rec
+}
+
+// spanFromContext returns span started by the caller, e.g. by Check for comment-check call
+func spanFromContext(ctx context.Context) *spanRecorder {
+	rec, _ := ctx.Value(spanKey{}).(*spanRecorder)
+	return rec
+}
+
+func (s *spanRecorder) recordCall(statusCode, retries int) {
+	if s != nil {
+		s.info.StatusCode = statusCode
+		s.info.Retries = retries
+	}
+}
+
+func (s *spanRecorder) recordResult(result *CheckResult) {
+	if s != nil && result != nil {
+		s.info.Verdict = result.Verdict.String()
+		s.info.CacheHit = result.CacheHit
+	}
+}
+
+func (s *spanRecorder) end(err error) {
+	if s != nil {
+		s.info.Err = err
+		s.span.End(s.info)
+	}
+}
+
+// traced runs f inside span named endpoint
+func (c *Client) traced(ctx context.Context, endpoint string, f func(context.Context) error) error {
+	ctx, span := c.startSpan(ctx, endpoint)
+	err := f(ctx)
+	span.end(err)
+
+	return err
+}
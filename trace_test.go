@@ -0,0 +1,80 @@
+package akismet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTracer struct {
+	started []string
+	ended   []SpanInfo
+}
+
+type testSpan struct {
+	tracer *testTracer
+}
+
+func (t *testTracer) Start(ctx context.Context, endpoint string) (context.Context, Span) {
+	t.started = append(t.started, endpoint)
+	return ctx, &testSpan{tracer: t}
+}
+
+func (s *testSpan) End(info SpanInfo) {
+	s.tracer.ended = append(s.tracer.ended, info)
+}
+
+func TestTracerCheck(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Write([]byte("true"))
+	}))
+	defer server.Close()
+
+	tracer := &testTracer{}
+	client := NewClient("test_api_key", "test_site",
+		WithBaseURL(server.URL),
+		WithTracer(tracer),
+		WithRetry(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+		WithCache(NewLRUCache(10, 0), nil),
+	)
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent", Content: "spam"}
+	_, err := client.Check(options)
+	assert.Nil(t, err)
+	_, err = client.Check(options)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"comment-check", "comment-check"}, tracer.started)
+	assert.Equal(t, []SpanInfo{
+		{Endpoint: "comment-check", Verdict: "spam", StatusCode: 200, Retries: 1},
+		{Endpoint: "comment-check", Verdict: "spam", CacheHit: true},
+	}, tracer.ended)
+}
+
+func TestTracerSubmitError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("invalid"))
+	}))
+	defer server.Close()
+
+	tracer := &testTracer{}
+	client := NewClient("test_api_key", "test_site", WithBaseURL(server.URL), WithTracer(tracer))
+	options := Options{UserIP: "127.0.0.1", UserAgent: "TestUserAgent"}
+	assert.Error(t, client.SubmitSpam(options))
+
+	if assert.Len(t, tracer.ended, 1) {
+		assert.Equal(t, "submit-spam", tracer.ended[0].Endpoint)
+		assert.Equal(t, 200, tracer.ended[0].StatusCode)
+		assert.True(t, errors.Is(tracer.ended[0].Err, ErrInvalidRequest))
+	}
+}